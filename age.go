@@ -0,0 +1,71 @@
+package bsdate
+
+import "errors"
+
+// Age is the time that passed since a date, counted in completed BS years, months and days
+type Age struct {
+	Years  int
+	Months int
+	Days   int
+}
+
+// AgeAt returns the age on the date "on" of someone born on "birth".
+// A month is completed on the same day-of-month as the birth, or on the last day of the month if that month is too short,
+// see ReachesAgeOn for the details. ErrMixedCalendars is returned if the dates belong to different calendars
+func AgeAt(birth, on Date) (Age, error) {
+	var calendar = CalendarOf(birth)
+	order, err := calendar.Compare(on, birth)
+	if err != nil {
+		return Age{}, err
+	}
+	if order < 0 {
+		return Age{}, errors.New("date is before the date of birth")
+	}
+	var months = (on.GetYear()-birth.GetYear())*12 + on.GetMonth() - birth.GetMonth()
+	anniversary, err := addMonthsClamped(birth, months)
+	if err != nil {
+		return Age{}, err
+	}
+	if order, _ = calendar.Compare(anniversary, on); order > 0 {
+		months--
+		anniversary, err = addMonthsClamped(birth, months)
+		if err != nil {
+			return Age{}, err
+		}
+	}
+	days, err := calendar.DaysBetween(anniversary, on)
+	if err != nil {
+		return Age{}, err
+	}
+	return Age{Years: months / 12, Months: months % 12, Days: days}, nil
+}
+
+// ReachesAgeOn returns the BS date on which someone born on "birth" completes the given amount of years.
+// That is the same day and month, "years" years later.
+// Because the length of BS months changes from year to year the day of birth might not exist in the month of
+// the anniversary, e.g. someone born on Ashadh 32 in a year Ashadh only has 31 days. In that case the age is
+// reached on the last day of that month (Ashadh 31), never in the following month.
+func ReachesAgeOn(birth Date, years int) (Date, error) {
+	if years < 0 {
+		return nil, errors.New("age cannot be negative")
+	}
+	return addMonthsClamped(birth, years*12)
+}
+
+// addMonthsClamped moves the date by the given amount of months,
+// if the day does not exist in the resulting month the last day of that month is used.
+// The result belongs to the calendar of d
+func addMonthsClamped(d Date, months int) (Date, error) {
+	var calendar = CalendarOf(d)
+	var monthIndex = d.GetYear()*12 + d.GetMonth() - 1 + months
+	var year, month = monthIndex / 12, monthIndex%12 + 1
+	daysInMonth, err := calendar.DaysInMonth(year, month)
+	if err != nil {
+		return nil, err
+	}
+	var day = d.GetDay()
+	if day > daysInMonth {
+		day = daysInMonth
+	}
+	return calendar.New(day, month, year)
+}
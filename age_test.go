@@ -0,0 +1,98 @@
+package bsdate
+
+import (
+	"github.com/magiconair/properties/assert"
+	"strconv"
+	"testing"
+)
+
+var ages = []struct {
+	birth    string
+	on       string
+	expected Age
+}{
+	{"2063-01-15", "2063-01-15", Age{0, 0, 0}},
+	{"2063-01-15", "2081-01-14", Age{17, 11, 29}}, //Chaitra 2080 has 30 days
+	{"2063-01-15", "2081-01-15", Age{18, 0, 0}},
+	{"2063-01-15", "2081-01-16", Age{18, 0, 1}},
+	{"2063-01-15", "2081-02-14", Age{18, 0, 30}},
+	{"2063-01-15", "2081-02-15", Age{18, 1, 0}},
+	{"2076-02-32", "2077-02-31", Age{0, 11, 31}}, //Jestha 2077 has 32 days, the birthday is still to come
+	{"2076-02-32", "2077-02-32", Age{1, 0, 0}},
+	{"2076-02-32", "2081-02-31", Age{5, 0, 0}}, //Jestha 2081 has only 31 days, so its last day counts
+	{"2076-02-32", "2081-03-01", Age{5, 0, 1}},
+	{"2076-02-32", "2076-03-31", Age{0, 1, 0}}, //Ashadh 2076 has 31 days, the monthly anniversary is on its last day
+	{"2076-02-32", "2076-04-01", Age{0, 1, 1}},
+	{"2080-12-30", "2081-01-01", Age{0, 0, 1}},
+	{"2023-09-10", "2081-03-31", Age{57, 6, 21}},
+	{"2023-09-10", "2081-09-10", Age{58, 0, 0}},    //pension age
+	{"1970-01-01", "2100-12-30", Age{130, 11, 29}}, //all the data we have
+}
+
+func TestAgeAt(t *testing.T) {
+	for _, testCase := range ages {
+		t.Run(testCase.birth+"/"+testCase.on, func(t *testing.T) {
+			age, err := AgeAt(mustNew(t, testCase.birth), mustNew(t, testCase.on))
+			assert.Equal(t, err, nil)
+			assert.Equal(t, age, testCase.expected)
+		})
+	}
+}
+
+func TestAgeAtBeforeBirth(t *testing.T) {
+	_, err := AgeAt(mustNew(t, "2063-01-15"), mustNew(t, "2063-01-14"))
+	assert.Equal(t, err.Error(), "date is before the date of birth")
+}
+
+var anniversaries = []struct {
+	birth    string
+	years    int
+	expected string
+}{
+	{"2063-01-15", 18, "2081-01-15"},
+	{"2063-01-15", 0, "2063-01-15"},
+	{"2076-02-32", 1, "2077-02-32"},
+	{"2076-02-32", 5, "2081-02-31"}, //Jestha 2081 has only 31 days
+	{"2023-09-10", 58, "2081-09-10"},
+	{"2021-03-32", 59, "2080-03-31"}, //Ashadh 2080 has only 31 days
+}
+
+func TestReachesAgeOn(t *testing.T) {
+	for _, testCase := range anniversaries {
+		t.Run(testCase.birth+"+"+strconv.Itoa(testCase.years), func(t *testing.T) {
+			var birth = mustNew(t, testCase.birth)
+			anniversary, err := ReachesAgeOn(birth, testCase.years)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, formatForTest(anniversary), testCase.expected)
+
+			age, err := AgeAt(birth, anniversary)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, age, Age{testCase.years, 0, 0})
+		})
+	}
+}
+
+func TestReachesAgeOnOutOfData(t *testing.T) {
+	_, err := ReachesAgeOn(mustNew(t, "2081-01-15"), 20)
+	assert.Equal(t, err, ErrMissingData)
+	_, err = ReachesAgeOn(mustNew(t, "2081-01-15"), -1)
+	assert.Equal(t, err.Error(), "age cannot be negative")
+}
+
+func TestAgeAtInVendorCalendar(t *testing.T) {
+	var vendor = vendorCalendar(t)
+	age, err := AgeAt(mustNewIn(t, vendor, "2080-01-30"), mustNewIn(t, vendor, "2081-02-05"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, age, Age{Years: 1, Months: 0, Days: 5}) //Baisakh 2081 has 30 days in the vendor calendar
+	age, err = AgeAt(mustNewIn(t, vendor, "2080-02-32"), mustNewIn(t, vendor, "2081-02-32"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, age, Age{Years: 1})
+
+	anniversary, err := ReachesAgeOn(mustNewIn(t, vendor, "2080-02-32"), 1)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, formatForTest(anniversary), "2081-02-32")
+	assert.Equal(t, CalendarOf(anniversary), vendor)
+
+	_, err = AgeAt(mustNew(t, "2080-01-30"), mustNewIn(t, vendor, "2081-02-05"))
+	assert.Equal(t, err, ErrMixedCalendars)
+}
@@ -0,0 +1,249 @@
+package bsdate
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// ApproxDate is a BS date together with its gregorian date, that might be projected beyond the data of this module.
+// The months of years without data are extrapolated from the average length of the BS year and the average start of
+// every month in the data, so the result is an estimate and not an official date
+type ApproxDate struct {
+	Year, Month, Day int
+	Gregorian        time.Time
+	// Exact is true if the date is taken from the data of this module
+	Exact bool
+	// ErrorDays is how many days the official date can be away from the projected one, it is 0 for exact dates
+	ErrorDays int
+}
+
+// calendarModel holds the statistics of the data that are needed to project dates
+type calendarModel struct {
+	//version and years are the calendar data the statistics are taken from
+	version int64
+	years   calendarTable
+	//firstYear is the first year of the data, convertibleYear the first year whose 1st Baisakh can be converted
+	firstYear, convertibleYear, lastYear int
+	//starts are the days since 1970-01-01 AD of the 1st Baisakh of all years of the data
+	starts map[int]int
+	//averageYear is the average length of a year, averageOffsets the average days from 1st Baisakh to every month
+	averageYear    float64
+	averageOffsets [13]float64
+	//spread is how far the years of the data are away from the averages, growth is added per projected year
+	spread, growth float64
+}
+
+// maxYearSearch limits how many years ApproxFromGregorian moves away from its first guess
+const maxYearSearch = 10
+
+// getModel returns the statistics of the current calendar data, they are taken again after every update.
+// ErrMissingData is returned if less than two years of the data can be converted to gregorian
+func (c *Calendar) getModel() (*calendarModel, error) {
+	var data = c.snapshot()
+	c.modelMutex.Lock()
+	defer c.modelMutex.Unlock()
+	if c.model == nil || c.model.version != data.version {
+		model, err := newCalendarModel(c, data)
+		if err != nil {
+			return nil, err
+		}
+		c.model = model
+	}
+	return c.model, nil
+}
+
+func newCalendarModel(c *Calendar, data *calendarSnapshot) (*calendarModel, error) {
+	var years []int
+	for year := range data.years {
+		years = append(years, year)
+	}
+	sort.Ints(years)
+	var m = &calendarModel{
+		version:   data.version,
+		years:     data.years,
+		firstYear: years[0],
+		lastYear:  years[len(years)-1],
+		starts:    map[int]int{},
+	}
+	for _, year := range years {
+		d, _ := c.New(1, 1, year)
+		if gregorianDate, err := d.GetGregorianDate(); err == nil {
+			if m.convertibleYear == 0 {
+				m.convertibleYear = year
+			}
+			m.starts[year] = dayNumber(gregorianDate)
+		}
+	}
+	//the average year needs the starts of two years
+	if m.convertibleYear == 0 || m.convertibleYear == m.lastYear {
+		return nil, ErrMissingData
+	}
+	//the first years of the data cannot be converted (see GetGregorianDate), they are counted back from the first one that can
+	for year := m.convertibleYear - 1; year >= m.firstYear; year-- {
+		m.starts[year] = m.starts[year+1] - m.years.yearLength(year)
+	}
+
+	m.averageYear = float64(m.starts[m.lastYear]-m.starts[m.convertibleYear]) / float64(m.lastYear-m.convertibleYear)
+	//a few years of the data have impossible lengths, they and the years after them are left out of the statistics
+	var usable []int
+	for _, year := range years {
+		if year > m.convertibleYear && m.plausibleYear(year) && m.plausibleYear(year-1) {
+			usable = append(usable, year)
+		}
+	}
+	for _, year := range usable {
+		var offset = 0
+		for month := 1; month <= 12; month++ {
+			m.averageOffsets[month] += float64(offset) / float64(len(usable))
+			offset += m.years[year][month]
+		}
+	}
+	var yearSpread, monthSpread float64
+	for _, year := range usable {
+		var expected = float64(m.starts[m.convertibleYear]) + float64(year-m.convertibleYear)*m.averageYear
+		yearSpread = math.Max(yearSpread, math.Abs(float64(m.starts[year])-expected))
+		var offset = 0
+		for month := 1; month <= 12; month++ {
+			monthSpread = math.Max(monthSpread, math.Abs(float64(offset)-m.averageOffsets[month]))
+			offset += m.years[year][month]
+		}
+	}
+	m.spread = yearSpread + monthSpread
+	//the average year is measured between two starts that can each be yearSpread away from the average
+	m.growth = 2 * yearSpread / float64(m.lastYear-m.convertibleYear)
+	return m, nil
+}
+
+func (m *calendarModel) plausibleYear(year int) bool {
+	var length = m.years.yearLength(year)
+	return length == 365 || length == 366
+}
+
+// dayNumber counts the days since 1970-01-01 AD
+func dayNumber(t time.Time) int {
+	return int(math.Floor(float64(t.Unix()) / 86400))
+}
+
+func fromDayNumber(n int) time.Time {
+	return time.Unix(int64(n)*86400, 0).UTC()
+}
+
+// yearStart returns the day number of the 1st Baisakh of year, it is projected for years without data
+func (m *calendarModel) yearStart(year int) float64 {
+	if start, ok := m.starts[year]; ok {
+		return float64(start)
+	}
+	if year > m.lastYear {
+		return float64(m.starts[m.lastYear]+m.years.yearLength(m.lastYear)) + float64(year-m.lastYear-1)*m.averageYear
+	}
+	return float64(m.starts[m.firstYear]) - float64(m.firstYear-year)*m.averageYear
+}
+
+// monthStart returns the day number of the first day of the month, month 13 is the start of the next year
+func (m *calendarModel) monthStart(year, month int) int {
+	if month == 13 {
+		return m.monthStart(year+1, 1)
+	}
+	if _, ok := m.years[year]; ok {
+		var start = m.starts[year]
+		for previous := 1; previous < month; previous++ {
+			start += m.years[year][previous]
+		}
+		return start
+	}
+	return int(math.Floor(m.yearStart(year) + m.averageOffsets[month] + 0.5))
+}
+
+// errorDays is the uncertainty of the dates of a projected year
+func (m *calendarModel) errorDays(year int) int {
+	var distance = 0
+	switch {
+	case year > m.lastYear:
+		distance = year - m.lastYear
+	case year < m.firstYear:
+		distance = m.firstYear - year
+	default:
+		return 0
+	}
+	//half a day is lost by rounding to whole days
+	return int(math.Ceil(m.spread + 0.5 + float64(distance)*m.growth))
+}
+
+func (m *calendarModel) approxDate(year, month, day int) ApproxDate {
+	var _, exact = m.years[year]
+	return ApproxDate{
+		Year:      year,
+		Month:     month,
+		Day:       day,
+		Gregorian: fromDayNumber(m.monthStart(year, month) + day - 1),
+		Exact:     exact,
+		ErrorDays: m.errorDays(year),
+	}
+}
+
+// ApproxToGregorian converts a BS date with the default calendar, see Calendar.ApproxToGregorian
+func ApproxToGregorian(day, month, year int) (ApproxDate, error) {
+	return defaultCalendar.ApproxToGregorian(day, month, year)
+}
+
+// ApproxToGregorian converts a BS date like NewFromGregorian does the other way round, but also projects years
+// without data. ErrInvalidDate is returned if the day does not exist in the (projected) month
+func (c *Calendar) ApproxToGregorian(day, month, year int) (ApproxDate, error) {
+	if d, err := c.New(day, month, year); err == nil {
+		if gregorianDate, err := d.GetGregorianDate(); err == nil {
+			return ApproxDate{Year: year, Month: month, Day: day, Gregorian: gregorianDate, Exact: true}, nil
+		}
+	}
+	if month < 1 || month > 12 || day < 1 {
+		return ApproxDate{}, ErrInvalidDate
+	}
+	m, err := c.getModel()
+	if err != nil {
+		return ApproxDate{}, err
+	}
+	if day > m.monthStart(year, month+1)-m.monthStart(year, month) {
+		return ApproxDate{}, ErrInvalidDate
+	}
+	return m.approxDate(year, month, day), nil
+}
+
+// ApproxFromGregorian converts a gregorian date with the default calendar, see Calendar.ApproxFromGregorian
+func ApproxFromGregorian(gregorianDay, gregorianMonth, gregorianYear int) (ApproxDate, error) {
+	return defaultCalendar.ApproxFromGregorian(gregorianDay, gregorianMonth, gregorianYear)
+}
+
+// ApproxFromGregorian converts a gregorian date to BS like NewFromGregorian, but also projects years without data
+func (c *Calendar) ApproxFromGregorian(gregorianDay, gregorianMonth, gregorianYear int) (ApproxDate, error) {
+	var gregorianDate = time.Date(gregorianYear, time.Month(gregorianMonth), gregorianDay, 0, 0, 0, 0, time.UTC)
+	if gregorianDate.Day() != gregorianDay || int(gregorianDate.Month()) != gregorianMonth {
+		return ApproxDate{}, ErrInvalidDate
+	}
+	if d, err := c.FromGregorian(gregorianDay, gregorianMonth, gregorianYear); err == nil {
+		return ApproxDate{
+			Year: d.GetYear(), Month: d.GetMonth(), Day: d.GetDay(), Gregorian: gregorianDate, Exact: true,
+		}, nil
+	}
+	m, err := c.getModel()
+	if err != nil {
+		return ApproxDate{}, err
+	}
+	var n = dayNumber(gregorianDate)
+	//the BS year starts in April, so it is about 57 years ahead
+	var year = gregorianYear + 56
+	for tries := 0; n < m.monthStart(year, 1) || n >= m.monthStart(year, 13); tries++ {
+		if tries == maxYearSearch {
+			return ApproxDate{}, ErrMissingData
+		}
+		if n < m.monthStart(year, 1) {
+			year--
+		} else {
+			year++
+		}
+	}
+	var month = 1
+	for n >= m.monthStart(year, month+1) {
+		month++
+	}
+	return m.approxDate(year, month, n-m.monthStart(year, month)+1), nil
+}
@@ -0,0 +1,129 @@
+package bsdate
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestApproxToGregorian(t *testing.T) {
+	var tests = []struct {
+		date      string
+		expected  string
+		exact     bool
+		errorDays int
+	}{
+		{"2081-03-32", "2024-07-15", true, 0},
+		{"2100-12-30", "2044-04-12", true, 0},
+		//the first months of the data cannot be converted by GetGregorianDate, but their lengths are known
+		{"1970-01-01", "1913-04-13", true, 0},
+		{"2101-01-01", "2044-04-13", false, 4},
+		{"2150-01-15", "2093-04-28", false, 5},
+		{"1969-01-01", "1912-04-13", false, 4},
+	}
+	for _, test := range tests {
+		t.Run(test.date, func(t *testing.T) {
+			var year, month, day int
+			_, _ = fmt.Sscanf(test.date, "%d-%d-%d", &year, &month, &day)
+			approx, err := ApproxToGregorian(day, month, year)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, approx.Gregorian.Format("2006-01-02"), test.expected)
+			assert.Equal(t, approx.Exact, test.exact)
+			assert.Equal(t, approx.ErrorDays, test.errorDays)
+		})
+	}
+}
+
+func TestApproxFromGregorian(t *testing.T) {
+	var tests = []struct {
+		gregorian string
+		expected  string
+		exact     bool
+	}{
+		{"2024-07-15", "2081-03-32", true},
+		{"2044-04-12", "2100-12-30", true},
+		{"2044-04-13", "2101-01-01", false},
+		{"2093-04-28", "2150-01-15", false},
+		{"1913-12-15", "1970-09-01", true},
+		{"1912-04-13", "1969-01-01", false},
+	}
+	for _, test := range tests {
+		t.Run(test.gregorian, func(t *testing.T) {
+			gregorianDate, _ := time.Parse("2006-01-02", test.gregorian)
+			approx, err := ApproxFromGregorian(gregorianDate.Day(), int(gregorianDate.Month()), gregorianDate.Year())
+			assert.Equal(t, err, nil)
+			assert.Equal(t, fmt.Sprintf("%04d-%02d-%02d", approx.Year, approx.Month, approx.Day), test.expected)
+			assert.Equal(t, approx.Exact, test.exact)
+			assert.Equal(t, approx.Gregorian, gregorianDate)
+		})
+	}
+}
+
+// the round trip is only tested for projected dates, some years of the data do not convert back and forth
+func TestApproxRoundTrip(t *testing.T) {
+	for n := dayNumber(time.Date(2044, 4, 13, 0, 0, 0, 0, time.UTC)); n < dayNumber(time.Date(2200, 1, 1, 0, 0, 0, 0, time.UTC)); n += 17 {
+		var gregorianDate = fromDayNumber(n)
+		approx, err := ApproxFromGregorian(gregorianDate.Day(), int(gregorianDate.Month()), gregorianDate.Year())
+		assert.Equal(t, err, nil)
+		back, err := ApproxToGregorian(approx.Day, approx.Month, approx.Year)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, back.Gregorian, gregorianDate)
+	}
+}
+
+func TestApproxMonthLengths(t *testing.T) {
+	m, err := defaultCalendar.getModel()
+	assert.Equal(t, err, nil)
+	for year := 2101; year < 2300; year++ {
+		for month := 1; month <= 12; month++ {
+			var days = m.monthStart(year, month+1) - m.monthStart(year, month)
+			assert.Equal(t, days >= 29 && days <= 32, true)
+		}
+		var length = m.monthStart(year+1, 1) - m.monthStart(year, 1)
+		assert.Equal(t, length == 365 || length == 366, true)
+	}
+}
+
+func TestApproxInvalid(t *testing.T) {
+	_, err := ApproxToGregorian(32, 9, 2150)
+	assert.Equal(t, err, ErrInvalidDate)
+	_, err = ApproxToGregorian(1, 13, 2150)
+	assert.Equal(t, err, ErrInvalidDate)
+	_, err = ApproxToGregorian(0, 1, 2150)
+	assert.Equal(t, err, ErrInvalidDate)
+	_, err = ApproxFromGregorian(30, 2, 2100)
+	assert.Equal(t, err, ErrInvalidDate)
+}
+
+func TestApproxSmallCalendar(t *testing.T) {
+	var all = CalendarData()
+	var smallCalendar = func(years ...int) *Calendar {
+		var data = map[int]YearData{}
+		for _, year := range years {
+			data[year] = all[year]
+		}
+		calendar, err := NewCalendar("small", data)
+		assert.Equal(t, err, nil)
+		return calendar
+	}
+
+	//only 2081 can be converted, there is no average year to project with
+	var tooSmall = smallCalendar(2080, 2081)
+	_, err := tooSmall.ApproxFromGregorian(1, 1, 2040)
+	assert.Equal(t, err, ErrMissingData)
+	_, err = tooSmall.ApproxToGregorian(1, 1, 2090)
+	assert.Equal(t, err, ErrMissingData)
+	exact, err := tooSmall.ApproxToGregorian(1, 1, 2081)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, exact.Gregorian.Format("2006-01-02"), "2024-04-13")
+
+	//two convertible years are enough for a projection, even if a rough one
+	var small = smallCalendar(2080, 2081, 2082)
+	approx, err := small.ApproxFromGregorian(1, 1, 2040)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, approx.Exact, false)
+	assert.Equal(t, approx.Year, 2096)
+	assert.Equal(t, approx.Month, 9)
+}
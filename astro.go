@@ -0,0 +1,162 @@
+package bsdate
+
+import (
+	"math"
+	"time"
+)
+
+//low precision formulas from Jean Meeus, Astronomical Algorithms, 2nd edition.
+//The longitudes are good to a few hundredths of a degree, which puts the start and end of a tithi within a few
+//minutes of the published panchanga tables. Dynamical time and universal time are treated as being the same.
+
+const (
+	j2000              = 2451545.0
+	synodicMonth       = 29.530588853
+	kathmanduLatitude  = 27.7172
+	kathmanduLongitude = 85.3240
+)
+
+func julianDay(t time.Time) float64 {
+	return float64(t.Unix())/86400 + 2440587.5
+}
+
+func julianCenturies(jd float64) float64 {
+	return (jd - j2000) / 36525
+}
+
+func normalizeDegrees(degrees float64) float64 {
+	degrees = math.Mod(degrees, 360)
+	if degrees < 0 {
+		degrees += 360
+	}
+	return degrees
+}
+
+func sinDegrees(degrees float64) float64 {
+	return math.Sin(degrees * math.Pi / 180)
+}
+
+func cosDegrees(degrees float64) float64 {
+	return math.Cos(degrees * math.Pi / 180)
+}
+
+// sunLongitude is the tropical longitude of the sun corrected for aberration (Meeus chapter 25)
+func sunLongitude(jd float64) float64 {
+	var t = julianCenturies(jd)
+	var meanLongitude = 280.46646 + 36000.76983*t + 0.0003032*t*t
+	var meanAnomaly = 357.52911 + 35999.05029*t - 0.0001537*t*t
+	var center = (1.914602-0.004817*t-0.000014*t*t)*sinDegrees(meanAnomaly) +
+		(0.019993-0.000101*t)*sinDegrees(2*meanAnomaly) +
+		0.000289*sinDegrees(3*meanAnomaly)
+	return normalizeDegrees(meanLongitude + center - 0.00569)
+}
+
+// periodic terms for the longitude of the moon (Meeus table 47.A)
+// multiples of D, M, M', F and the coefficient in 0.000001 degrees
+var moonLongitudeTerms = [][5]float64{
+	{0, 0, 1, 0, 6288774}, {2, 0, -1, 0, 1274027}, {2, 0, 0, 0, 658314}, {0, 0, 2, 0, 213618},
+	{0, 1, 0, 0, -185116}, {0, 0, 0, 2, -114332}, {2, 0, -2, 0, 58793}, {2, -1, -1, 0, 57066},
+	{2, 0, 1, 0, 53322}, {2, -1, 0, 0, 45758}, {0, 1, -1, 0, -40923}, {1, 0, 0, 0, -34720},
+	{0, 1, 1, 0, -30383}, {2, 0, 0, -2, 15327}, {0, 0, 1, 2, -12528}, {0, 0, 1, -2, 10980},
+	{4, 0, -1, 0, 10675}, {0, 0, 3, 0, 10034}, {4, 0, -2, 0, 8548}, {2, 1, -1, 0, -7888},
+	{2, 1, 0, 0, -6766}, {1, 0, -1, 0, -5163}, {1, 1, 0, 0, 4987}, {2, -1, 1, 0, 4036},
+	{2, 0, 2, 0, 3994}, {4, 0, 0, 0, 3861}, {2, 0, -3, 0, 3665}, {0, 1, -2, 0, -2689},
+	{2, 0, -1, 2, -2602}, {2, -1, -2, 0, 2390}, {1, 0, 1, 0, -2348}, {2, -2, 0, 0, 2236},
+	{0, 1, 2, 0, -2120}, {0, 2, 0, 0, -2069}, {2, -2, -1, 0, 2048}, {2, 0, 1, -2, -1773},
+	{2, 0, 0, 2, -1595}, {4, -1, -1, 0, 1215}, {0, 0, 2, 2, -1110}, {3, 0, -1, 0, -892},
+	{2, 1, 1, 0, -810}, {4, -1, -2, 0, 759}, {0, 2, -1, 0, -713}, {2, 2, -1, 0, -700},
+	{2, 1, -2, 0, 691}, {2, -1, 0, -2, 596}, {4, 0, 1, 0, 549}, {0, 0, 4, 0, 537},
+	{4, -1, 0, 0, 520}, {1, 0, -2, 0, -487}, {2, 1, 0, -2, -399}, {0, 0, 2, -2, -381},
+	{1, 1, 1, 0, 351}, {3, 0, -2, 0, -340}, {4, 0, -3, 0, 330}, {2, -1, 2, 0, 327},
+	{0, 2, 1, 0, -323}, {1, 1, -1, 0, 299}, {2, 0, 3, 0, 294},
+}
+
+// moonLongitude is the tropical longitude of the moon (Meeus chapter 47)
+func moonLongitude(jd float64) float64 {
+	var t = julianCenturies(jd)
+	var meanLongitude = 218.3164477 + 481267.88123421*t - 0.0015786*t*t + t*t*t/538841 - t*t*t*t/65194000
+	var elongation = 297.8501921 + 445267.1114034*t - 0.0018819*t*t + t*t*t/545868 - t*t*t*t/113065000
+	var sunAnomaly = 357.5291092 + 35999.0502909*t - 0.0001536*t*t + t*t*t/24490000
+	var moonAnomaly = 134.9633964 + 477198.8675055*t + 0.0087414*t*t + t*t*t/69699 - t*t*t*t/14712000
+	var latitudeArgument = 93.2720950 + 483202.0175233*t - 0.0036539*t*t - t*t*t/3526000 + t*t*t*t/863310000
+	var eccentricity = 1 - 0.002516*t - 0.0000074*t*t
+
+	var sum = 0.0
+	for _, term := range moonLongitudeTerms {
+		var argument = term[0]*elongation + term[1]*sunAnomaly + term[2]*moonAnomaly + term[3]*latitudeArgument
+		var coefficient = term[4]
+		switch math.Abs(term[1]) {
+		case 1:
+			coefficient *= eccentricity
+		case 2:
+			coefficient *= eccentricity * eccentricity
+		}
+		sum += coefficient * sinDegrees(argument)
+	}
+	//additive terms caused by Venus, Jupiter and the flattening of the earth
+	sum += 3958*sinDegrees(119.75+131.849*t) +
+		1962*sinDegrees(meanLongitude-latitudeArgument) +
+		318*sinDegrees(53.09+479264.290*t)
+
+	return normalizeDegrees(meanLongitude + sum/1000000)
+}
+
+// lunarElongation is the angle the moon is ahead of the sun, 0 at new moon and 180 at full moon
+func lunarElongation(jd float64) float64 {
+	return normalizeDegrees(moonLongitude(jd) - sunLongitude(jd))
+}
+
+// ayanamsa is the Lahiri (Chitrapaksha) ayanamsa used to get sidereal longitudes
+func ayanamsa(jd float64) float64 {
+	return 23.85306 + 1.39604*julianCenturies(jd)
+}
+
+// siderealSunLongitude is the longitude of the sun measured from the start of Mesha
+func siderealSunLongitude(jd float64) float64 {
+	return normalizeDegrees(sunLongitude(jd) - ayanamsa(jd))
+}
+
+// newMoonNear finds the new moon closest to the estimate, it has to be less than half a month away
+func newMoonNear(estimate float64) float64 {
+	var jd = estimate
+	for i := 0; i < 20; i++ {
+		var elongation = lunarElongation(jd)
+		if elongation > 180 {
+			elongation -= 360
+		}
+		//the moon moves about 12.19 degrees a day away from the sun
+		var correction = elongation / (360 / synodicMonth)
+		jd -= correction
+		if math.Abs(correction) < 0.00001 {
+			break
+		}
+	}
+	return jd
+}
+
+// newMoonBefore returns the last new moon at or before jd
+func newMoonBefore(jd float64) float64 {
+	var newMoon = newMoonNear(jd - lunarElongation(jd)/(360/synodicMonth))
+	if newMoon > jd {
+		newMoon = newMoonNear(newMoon - synodicMonth)
+	}
+	return newMoon
+}
+
+// sunTimes returns the julian days of sunrise and sunset in Kathmandu on the given gregorian day,
+// using the sunrise equation with the sun's position calculated at noon
+func sunTimes(day time.Time) (float64, float64) {
+	var noon = time.Date(day.Year(), day.Month(), day.Day(), 12, 0, 0, 0, time.UTC)
+	var daysSinceJ2000 = math.Floor(julianDay(noon) - j2000 + 0.5)
+	var meanSolarTime = daysSinceJ2000 - kathmanduLongitude/360
+	var meanAnomaly = normalizeDegrees(357.5291 + 0.98560028*meanSolarTime)
+	var center = 1.9148*sinDegrees(meanAnomaly) + 0.02*sinDegrees(2*meanAnomaly) + 0.0003*sinDegrees(3*meanAnomaly)
+	var eclipticLongitude = normalizeDegrees(meanAnomaly + center + 180 + 102.9372)
+	var transit = j2000 + meanSolarTime + 0.0053*sinDegrees(meanAnomaly) - 0.0069*sinDegrees(2*eclipticLongitude)
+	var sinDeclination = sinDegrees(eclipticLongitude) * sinDegrees(23.44)
+	var cosDeclination = math.Cos(math.Asin(sinDeclination))
+	var cosHourAngle = (sinDegrees(-0.833) - sinDegrees(kathmanduLatitude)*sinDeclination) /
+		(cosDegrees(kathmanduLatitude) * cosDeclination)
+	var hourAngle = math.Acos(cosHourAngle) * 180 / math.Pi
+	return transit - hourAngle/360, transit + hourAngle/360
+}
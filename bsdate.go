@@ -2,6 +2,7 @@ package bsdate
 
 import (
 	"errors"
+	"sort"
 	"time"
 )
 
@@ -12,16 +13,24 @@ type Date interface {
 	GetMonthName() string
 	GetGregorianDate() (time.Time, error)
 }
+
+var (
+	ErrInvalidDate      = errors.New("not a valid date")
+	ErrInvalidMonthType = errors.New("month has to be of value int or string")
+	ErrMissingData      = errors.New("cannot convert date, invalid or missing data")
+)
+
 type date struct {
-	Day        int
-	Month      int
-	Year       int
-	MonthNames [12]string
+	Day   int
+	Month int
+	Year  int
+	//calendar is the calendar the date belongs to, nil means the default calendar
+	calendar *Calendar
 }
 
 
 // nepali year : day in Paush for 1st Jan, no of days in Baisakh, no of days in Jestha, no of days in Ashadh, ..
-var calendardata = map[int][13]int {
+var builtinCalendarData = calendarTable {
 	1970: [13]int{18, 31, 31, 32, 31, 31, 31, 30, 29, 30, 29, 30, 30},
 	1971: [13]int{18, 31, 31, 32, 31, 32, 30, 30, 29, 30, 29, 30, 30},
 	1972: [13]int{17, 31, 32, 31, 32, 31, 30, 30, 30, 29, 29, 30, 30},
@@ -159,77 +168,84 @@ var calendardata = map[int][13]int {
 	2100: [13]int{17, 31, 32, 31, 32, 30, 31, 30, 29, 30, 29, 30, 30},
 }
 
+// MonthNames are the romanized names of the months, they are kept for compatibility.
+// Use the MonthNames of a Locale instead, e.g. English or Nepali
 var MonthNames = [12]string{
 	"Baisakh", "Jestha", "Ashadh", "Shrawan", "Bhadra", "Ashwin", "Kartik",
 	"Mangsir", "Paush", "Mangh", "Falgun", "Chaitra",
 }
 
+// New creates a date of the default calendar, see Calendar.New
 func New(Day int, Month interface{}, Year int) (Date, error) {
+	return defaultCalendar.New(Day, Month, Year)
+}
+
+// New creates a date of the calendar, the month can be given as number or as name
+func (c *Calendar) New(Day int, Month interface{}, Year int) (Date, error) {
 	var MonthInt int
 	switch Month.(type) {
 	case string:
-		for i, v := range MonthNames {
-			if v == Month.(string) {
-				MonthInt = i + 1
-				break
-			}
-		}
+		//the names of all registered locales are accepted, e.g. "Baisakh", "baishakh" or "बैशाख"
+		MonthInt, _ = ResolveMonthName(Month.(string))
 	case int:
 		MonthInt = Month.(int)
 	default:
-		return nil, errors.New("month has to be of value int or string")
-	}
-	d := date{
-		Day:   Day,
-		Month: MonthInt,
-		Year:  Year,
+		return nil, ErrInvalidMonthType
 	}
+	d := c.date(Day, MonthInt, Year)
 	if !d.isValid() {
-		return nil, errors.New("not a valid date")
+		return nil, ErrInvalidDate
 	}
 	return d, nil
 }
 
+// NewFromGregorian converts a gregorian date to a date of the default calendar, see Calendar.FromGregorian
 func NewFromGregorian(gregorianDay, gregorianMonth, gregorianYear int) (Date, error) {
+	return defaultCalendar.FromGregorian(gregorianDay, gregorianMonth, gregorianYear)
+}
+
+// FromGregorian converts a gregorian date to a date of the calendar
+func (c *Calendar) FromGregorian(gregorianDay, gregorianMonth, gregorianYear int) (Date, error) {
+	var data = c.snapshot().years
 	var bsYear = gregorianYear + 56         //first rough calculation, might become 57 later
 	var bsMonth = 9                         //Jan 1 always fall in BS month Paush which is the 9th month
 	var daysSinceJanFirstToEndOfBsMonth int //days calculated from 1st Jan till the end of the actual BS month,
 	                                        // we use this value to check if the gregorian Date is in the actual BS month
 
-	if _, ok := calendardata[bsYear]; !ok {
-		return nil, errors.New("cannot convert date, invalid or missing data")
+	if _, ok := data[bsYear]; !ok {
+		return nil, ErrMissingData
 	}
 
 	// Months with 31 days
 	if gregorianMonth == 2 || gregorianMonth == 4 || gregorianMonth == 6 ||
 		gregorianMonth == 9 || gregorianMonth == 11 {
 		if gregorianDay > 30 {
-			return nil, errors.New("cannot convert date, invalid or missing data")
+			return nil, ErrMissingData
 		}
 	}
 	// is the year leap year? Leap year has 29 days in february
 	if (gregorianYear%4 == 0 && gregorianYear%100 != 0) || gregorianYear%400 == 0 {
 		if gregorianMonth == 2 && gregorianDay > 29 {
-			return nil, errors.New("cannot convert date, invalid or missing data")
+			return nil, ErrMissingData
 		}
 	} else {
 		if gregorianMonth == 2 && gregorianDay > 28 {
-			return nil, errors.New("cannot convert date, invalid or missing data")
+			return nil, ErrMissingData
 		}
 	}
 
 	if gregorianMonth > 12 || gregorianDay > 31 {
-		return nil, errors.New("cannot convert date, invalid or missing data")
+		return nil, ErrMissingData
 	}
 
 	year := time.Date(gregorianYear, time.Month(gregorianMonth), gregorianDay, 0, 0, 0, 0, time.UTC)
 	var gregorianDayOfYear = year.YearDay()
 
 	//get the BS day in Paush (month 9) of 1st January
-	var dayOfFirstJanInPaush = calendardata[bsYear][0]
+	var dayOfFirstJanInPaush = data[bsYear][0]
 
 	//check how many days are left of Paush
-	daysSinceJanFirstToEndOfBsMonth = calendardata[bsYear][bsMonth] - dayOfFirstJanInPaush + 1
+	daysSinceJanFirstToEndOfBsMonth = data[bsYear][bsMonth] - dayOfFirstJanInPaush + 1
 
 	//If the gregorian day-of-year is smaller or equal to the sum of days between the 1st January and
 	//the end of the actual BS month we found the correct nepali month.
@@ -246,11 +262,11 @@ func NewFromGregorian(gregorianDay, gregorianMonth, gregorianYear int) (Date, er
 		if bsMonth > 12 {
 			bsMonth = 1
 			bsYear++
-			if _, ok := calendardata[bsYear]; !ok {
-				return nil, errors.New("cannot convert date, invalid or missing data")
+			if _, ok := data[bsYear]; !ok {
+				return nil, ErrMissingData
 			}
 		}
-		daysSinceJanFirstToEndOfBsMonth += calendardata[bsYear][bsMonth]
+		daysSinceJanFirstToEndOfBsMonth += data[bsYear][bsMonth]
 	}
 
 	//the last step is to calculate the nepali day-of-month
@@ -262,9 +278,9 @@ func NewFromGregorian(gregorianDay, gregorianMonth, gregorianYear int) (Date, er
 	//we calculated there are 43 days from 1st. January (17 Paush) till end of Mangh (29 days)
 	//when we subtract from this 43days the day-of-year of the the gregorian date (35), we know how far the searched day is away
 	//from the end of the nepali month. So we simply subtract this number from the amount of days in this month (30)
-	var bsDay = calendardata[bsYear][bsMonth] - (daysSinceJanFirstToEndOfBsMonth - gregorianDayOfYear)
+	var bsDay = data[bsYear][bsMonth] - (daysSinceJanFirstToEndOfBsMonth - gregorianDayOfYear)
 
-	return New(bsDay, bsMonth, bsYear)
+	return c.New(bsDay, bsMonth, bsYear)
 }
 
 func (d date) GetDay() int {
@@ -283,23 +299,23 @@ func (d date) GetMonthName() string {
 	return MonthNames[d.Month-1]
 }
 
-func (d date) isValid() bool {
-	//some rough testing
-	if d.Day <= 0 || d.Day > 32 || d.Month <= 0 || d.Month > 12 || d.Year <= 0 {
-		return false
-	}
-	//do we have data of that year?
-	if _, ok := calendardata[d.Year]; !ok {
-		return false
-	}
-	//does that particular month have so many days?
-	if d.Day > calendardata[d.Year][d.Month] {
-		return false
+func (d date) getCalendar() *Calendar {
+	if d.calendar == nil {
+		return defaultCalendar
 	}
-	return true
+	return d.calendar
+}
+
+func (d date) isValid() bool {
+	return d.getCalendar().snapshot().contains(d)
 }
 
 func (d date) GetGregorianDate() (time.Time, error) {
+	var data = d.getCalendar().snapshot().years
+	//the year might have been removed by a reset after the date was created
+	if _, ok := data[d.Year]; !ok {
+		return time.Time{}, ErrMissingData
+	}
 	var daysAfterJanFirstOfGregorianYear = 0 //we will add all the days that went by since the 1st.
 	                                         //January and then we can get the gregorian Date
 	var gregorianYear int
@@ -309,7 +325,7 @@ func (d date) GetGregorianDate() (time.Time, error) {
 
 	//get the correct year
 	//after the month of Paush (9) or in Paush but after 1st Jan in that BS year we have to subtract 56 years, else 57
-	if d.Month > 9 || (d.Month == 9 && d.Day >= calendardata[d.Year][0]) {
+	if d.Month > 9 || (d.Month == 9 && d.Day >= data[d.Year][0]) {
 		gregorianYear = d.Year - 56
 	} else {
 		gregorianYear = d.Year - 57
@@ -329,18 +345,18 @@ func (d date) GetGregorianDate() (time.Time, error) {
 			nepaliMonthToCheck = 12
 			nepaliYearToCheck--
 			//do we have data of that year?
-			if _, ok := calendardata[nepaliYearToCheck]; !ok {
-				return time.Time{}, errors.New("cannot convert date, invalid or missing data")
+			if _, ok := data[nepaliYearToCheck]; !ok {
+				return time.Time{}, ErrMissingData
 			}
 		}
-		daysAfterJanFirstOfGregorianYear += calendardata[nepaliYearToCheck][nepaliMonthToCheck]
+		daysAfterJanFirstOfGregorianYear += data[nepaliYearToCheck][nepaliMonthToCheck]
 	}
 
 	//If the date that has to be converted is in Paush (month no. 9) we have to do some other calculation
 	if d.Month == 9 {
 		//add the days that are passed since the first day of Paush and substract the amount of days that lie between
 		//1st. Jan and 1st Paush
-		daysAfterJanFirstOfGregorianYear += d.Day - calendardata[nepaliYearToCheck][0]
+		daysAfterJanFirstOfGregorianYear += d.Day - data[nepaliYearToCheck][0]
 
 		//for the first days of Paush we have now negative values
 		//so we calculate daysAfterJanFirstOfGregorianYear for the previous year
@@ -351,10 +367,110 @@ func (d date) GetGregorianDate() (time.Time, error) {
 		}
 	} else {
 		//add the days of Paush that are after 1st Jan
-		daysAfterJanFirstOfGregorianYear += calendardata[nepaliYearToCheck][9] - calendardata[nepaliYearToCheck][0]
+		daysAfterJanFirstOfGregorianYear += data[nepaliYearToCheck][9] - data[nepaliYearToCheck][0]
 	}
 
 	gregorianDate := time.Date(gregorianYear,1,1,0,0,0,0,time.UTC)
 	gregorianDate = gregorianDate.AddDate(0,0, daysAfterJanFirstOfGregorianYear)
 	return gregorianDate, nil
 }
+
+// FiscalYearStartMonth is the BS month the Nepali fiscal year starts with (Shrawan)
+const FiscalYearStartMonth = 4
+
+// NepalTime is the time zone of Nepal (Asia/Kathmandu).
+// If the system has no time zone database a fixed offset of +05:45 is used, which is correct since 1986
+var NepalTime = loadNepalTime()
+
+func loadNepalTime() *time.Location {
+	location, err := time.LoadLocation("Asia/Kathmandu")
+	if err != nil {
+		return time.FixedZone("+0545", 5*60*60+45*60)
+	}
+	return location
+}
+
+// YearMonth identifies a single month of a BS year
+type YearMonth struct {
+	Year  int
+	Month int
+}
+
+// DaysInMonth returns the amount of days the given BS month has in the default calendar
+func DaysInMonth(year, month int) (int, error) {
+	return defaultCalendar.DaysInMonth(year, month)
+}
+
+// DaysInMonth returns the amount of days the given BS month has
+func (c *Calendar) DaysInMonth(year, month int) (int, error) {
+	return c.snapshot().daysInMonth(year, month)
+}
+
+// AddDays returns the date that is the given amount of days after (or before, for negative values) d.
+// The result belongs to the calendar of d
+func AddDays(d Date, days int) (Date, error) {
+	return CalendarOf(d).AddDays(d, days)
+}
+
+// DaysBetween returns the amount of days from "from" till "to", negative if "to" is before "from".
+// ErrMixedCalendars is returned if the dates belong to different calendars, see Calendar.DaysBetween
+func DaysBetween(from, to Date) (int, error) {
+	return CalendarOf(from).DaysBetween(from, to)
+}
+
+// Compare returns -1 if a is before b, 0 if both are the same day and +1 if a is after b.
+// ErrMixedCalendars is returned if the dates belong to different calendars, see Calendar.Compare
+func Compare(a, b Date) (int, error) {
+	return CalendarOf(a).Compare(a, b)
+}
+
+// FiscalYear returns the BS year in which the fiscal year of d started
+// e.g. the fiscal year 2081/82 runs from 1st Shrawan 2081 till end of Ashadh 2082, so for any date in it 2081 is returned
+func FiscalYear(d Date) int {
+	if d.GetMonth() < FiscalYearStartMonth {
+		return d.GetYear() - 1
+	}
+	return d.GetYear()
+}
+
+func (t calendarTable) yearLength(year int) int {
+	var days = 0
+	for month := 1; month <= 12; month++ {
+		days += t[year][month]
+	}
+	return days
+}
+
+// toOrdinal counts the days between 1st Baisakh of the first year of the data and the given date.
+// The date has to be a day of the data, see contains
+func (s *calendarSnapshot) toOrdinal(d Date) int {
+	var days = s.starts[d.GetYear()-s.firstYear]
+	for month := 1; month < d.GetMonth(); month++ {
+		days += s.years[d.GetYear()][month]
+	}
+	return days + d.GetDay() - 1
+}
+
+// fromOrdinal is the reverse of toOrdinal, the date belongs to the calendar c
+func (c *Calendar) fromOrdinal(s *calendarSnapshot, ordinal int) (Date, error) {
+	if ordinal < 0 || ordinal >= s.starts[len(s.starts)-1] {
+		return nil, ErrMissingData
+	}
+	//the first year that starts after the ordinal is the one after the year of the ordinal
+	var index = sort.SearchInts(s.starts, ordinal+1) - 1
+	var year = s.firstYear + index
+	ordinal -= s.starts[index]
+	var month = 1
+	for ordinal >= s.years[year][month] {
+		ordinal -= s.years[year][month]
+		month++
+	}
+	return c.date(ordinal+1, month, year), nil
+}
+
+// Weekday returns the day of the week of d, see Calendar.Weekday.
+// It is time.Sunday for a date that is no longer part of the calendar data, e.g. after Reset removed its year
+func Weekday(d Date) time.Weekday {
+	weekday, _ := CalendarOf(d).Weekday(d)
+	return weekday
+}
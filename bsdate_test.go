@@ -219,3 +219,67 @@ func splitDateString(dateString string) (year int, month int, day int) {
 	year, _ = strconv.Atoi(splitedDate[0])
 	return
 }
+
+var addedDays = []struct {
+	date     string
+	days     int
+	expected string
+}{
+	{"2081-01-01", 0, "2081-01-01"},
+	{"2081-01-01", 1, "2081-01-02"},
+	{"2081-01-31", 1, "2081-02-01"},
+	{"2080-12-30", 1, "2081-01-01"},
+	{"2081-01-01", -1, "2080-12-30"},
+	{"2081-01-01", 366, "2082-01-01"}, //2081 has 366 days
+	{"1970-01-01", 0, "1970-01-01"},
+	{"1999-12-31", 1, "2000-01-01"},
+	{"2000-01-01", -1, "1999-12-31"},
+}
+
+func TestAddDays(t *testing.T) {
+	for _, testCase := range addedDays {
+		t.Run(testCase.date+"+"+strconv.Itoa(testCase.days), func(t *testing.T) {
+			var year, month, day = splitDateString(testCase.date)
+			nepaliDate, _ := New(day, month, year)
+			result, err := AddDays(nepaliDate, testCase.days)
+			assert.Equal(t, err, nil)
+			var expectedYear, expectedMonth, expectedDay = splitDateString(testCase.expected)
+			assert.Equal(t, result.GetYear(), expectedYear)
+			assert.Equal(t, result.GetMonth(), expectedMonth)
+			assert.Equal(t, result.GetDay(), expectedDay)
+			days, err := DaysBetween(nepaliDate, result)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, days, testCase.days)
+		})
+	}
+}
+
+func TestAddDaysOutOfData(t *testing.T) {
+	first, _ := New(1, 1, 1970)
+	result, err := AddDays(first, -1)
+	assert.Equal(t, err, ErrMissingData)
+	assert.Equal(t, result, nil)
+
+	last, _ := New(30, 12, 2100)
+	result, err = AddDays(last, 1)
+	assert.Equal(t, err, ErrMissingData)
+	assert.Equal(t, result, nil)
+}
+
+func TestDaysInMonth(t *testing.T) {
+	days, err := DaysInMonth(2076, 2)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, days, 32)
+
+	_, err = DaysInMonth(2076, 13)
+	assert.Equal(t, err, ErrInvalidDate)
+	_, err = DaysInMonth(2101, 1)
+	assert.Equal(t, err, ErrMissingData)
+}
+
+func TestFiscalYear(t *testing.T) {
+	shrawan, _ := New(1, 4, 2081)
+	ashadh, _ := New(32, 3, 2081)
+	assert.Equal(t, FiscalYear(shrawan), 2081)
+	assert.Equal(t, FiscalYear(ashadh), 2080)
+}
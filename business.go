@@ -0,0 +1,178 @@
+package bsdate
+
+import "time"
+
+// Adjustment is a convention to move a date that is not a business day to one that is
+type Adjustment int
+
+const (
+	// Unadjusted keeps the date as it is
+	Unadjusted Adjustment = iota
+	// Following moves to the next business day
+	Following
+	// Preceding moves to the previous business day
+	Preceding
+	// ModifiedFollowing moves to the next business day, unless that is in the next BS month, then it moves to the previous one
+	ModifiedFollowing
+	// ModifiedPreceding moves to the previous business day, unless that is in the previous BS month, then it moves to the next one
+	ModifiedPreceding
+)
+
+// BusinessCalendar knows which days are working days, by default only Saturday is a weekly holiday
+type BusinessCalendar struct {
+	weekend    map[time.Weekday]bool
+	holidays   map[[3]int]bool
+	registries []*HolidayRegistry
+}
+
+// NewBusinessCalendar creates a calendar with the given weekend days, if no weekend days are given Saturday is used
+func NewBusinessCalendar(weekend ...time.Weekday) *BusinessCalendar {
+	if len(weekend) == 0 {
+		weekend = []time.Weekday{time.Saturday}
+	}
+	var c = &BusinessCalendar{
+		weekend:  map[time.Weekday]bool{},
+		holidays: map[[3]int]bool{},
+	}
+	for _, day := range weekend {
+		c.weekend[day] = true
+	}
+	return c
+}
+
+// AddHoliday marks the given dates as public holidays
+func (c *BusinessCalendar) AddHoliday(dates ...Date) {
+	for _, d := range dates {
+		c.holidays[holidayKey(d)] = true
+	}
+}
+
+// RemoveHoliday turns the given dates back into normal days
+func (c *BusinessCalendar) RemoveHoliday(dates ...Date) {
+	for _, d := range dates {
+		delete(c.holidays, holidayKey(d))
+	}
+}
+
+// AddHolidayRegistry makes all holidays of the registry non business days
+func (c *BusinessCalendar) AddHolidayRegistry(r *HolidayRegistry) {
+	c.registries = append(c.registries, r)
+}
+
+func (c *BusinessCalendar) IsWeekend(d Date) bool {
+	return c.weekend[Weekday(d)]
+}
+
+func (c *BusinessCalendar) IsHoliday(d Date) bool {
+	if c.holidays[holidayKey(d)] {
+		return true
+	}
+	for _, r := range c.registries {
+		if r.IsHoliday(d) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *BusinessCalendar) IsBusinessDay(d Date) bool {
+	return !c.IsWeekend(d) && !c.IsHoliday(d)
+}
+
+// NextBusinessDay returns the first business day after d
+func (c *BusinessCalendar) NextBusinessDay(d Date) (Date, error) {
+	return c.step(d, 1)
+}
+
+// PreviousBusinessDay returns the last business day before d
+func (c *BusinessCalendar) PreviousBusinessDay(d Date) (Date, error) {
+	return c.step(d, -1)
+}
+
+// AddBusinessDays moves the given amount of business days forward (or backward for negative values).
+// d itself does not need to be a business day, adding 0 days returns d unchanged
+func (c *BusinessCalendar) AddBusinessDays(d Date, days int) (Date, error) {
+	var direction = 1
+	if days < 0 {
+		direction = -1
+		days = -days
+	}
+	var err error
+	for ; days > 0; days-- {
+		d, err = c.step(d, direction)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+// BusinessDaysBetween counts the business days from "from" (included) till "to" (excluded),
+// the result is negative if "to" is before "from". ErrMixedCalendars is returned if the dates belong to different
+// calendars
+func (c *BusinessCalendar) BusinessDaysBetween(from, to Date) (int, error) {
+	order, err := CalendarOf(from).Compare(from, to)
+	if err != nil {
+		return 0, err
+	}
+	var sign = 1
+	if order > 0 {
+		from, to = to, from
+		sign = -1
+	}
+	r, err := NewRange(from, to)
+	if err != nil {
+		return 0, err
+	}
+	var count = 0
+	for iterator := r.Iterate(); iterator.Next(); {
+		if c.IsBusinessDay(iterator.Date()) {
+			count++
+		}
+	}
+	return sign * count, nil
+}
+
+// Adjust moves d to a business day using the given convention, business days are never moved
+func (c *BusinessCalendar) Adjust(d Date, adjustment Adjustment) (Date, error) {
+	if adjustment == Unadjusted || c.IsBusinessDay(d) {
+		return d, nil
+	}
+	switch adjustment {
+	case Following:
+		return c.NextBusinessDay(d)
+	case Preceding:
+		return c.PreviousBusinessDay(d)
+	case ModifiedFollowing:
+		next, err := c.NextBusinessDay(d)
+		if err == nil && next.GetMonth() == d.GetMonth() {
+			return next, nil
+		}
+		return c.PreviousBusinessDay(d)
+	case ModifiedPreceding:
+		previous, err := c.PreviousBusinessDay(d)
+		if err == nil && previous.GetMonth() == d.GetMonth() {
+			return previous, nil
+		}
+		return c.NextBusinessDay(d)
+	}
+	return d, nil
+}
+
+// step moves day by day in the given direction till it reaches a business day
+func (c *BusinessCalendar) step(d Date, direction int) (Date, error) {
+	for {
+		var err error
+		d, err = AddDays(d, direction)
+		if err != nil {
+			return nil, err
+		}
+		if c.IsBusinessDay(d) {
+			return d, nil
+		}
+	}
+}
+
+func holidayKey(d Date) [3]int {
+	return [3]int{d.GetYear(), d.GetMonth(), d.GetDay()}
+}
@@ -0,0 +1,151 @@
+package bsdate
+
+import (
+	"github.com/magiconair/properties/assert"
+	"testing"
+	"time"
+)
+
+var weekdays = []struct {
+	date     string
+	expected time.Weekday
+}{
+	{"2081-01-01", time.Saturday},
+	{"2081-07-01", time.Thursday},
+	{"2068-01-01", time.Thursday},
+	{"1970-09-01", time.Monday},
+	{"1970-01-01", time.Sunday}, //this date cannot be converted to gregorian
+	{"1970-08-29", time.Sunday},
+}
+
+func TestWeekday(t *testing.T) {
+	for _, testCase := range weekdays {
+		t.Run(testCase.date, func(t *testing.T) {
+			assert.Equal(t, Weekday(mustNew(t, testCase.date)), testCase.expected)
+		})
+	}
+}
+
+// in Kartik 2081 the 1st is a Thursday, so the 3rd, 10th, 17th and 24th are Saturdays
+func kartik2081Calendar(t *testing.T) *BusinessCalendar {
+	var c = NewBusinessCalendar()
+	c.AddHoliday(mustNew(t, "2081-07-16"), mustNew(t, "2081-07-30"))
+	return c
+}
+
+func TestIsBusinessDay(t *testing.T) {
+	var c = kartik2081Calendar(t)
+	assert.Equal(t, c.IsBusinessDay(mustNew(t, "2081-07-15")), true)
+	assert.Equal(t, c.IsBusinessDay(mustNew(t, "2081-07-16")), false)
+	assert.Equal(t, c.IsBusinessDay(mustNew(t, "2081-07-17")), false)
+	assert.Equal(t, c.IsWeekend(mustNew(t, "2081-07-17")), true)
+	assert.Equal(t, c.IsHoliday(mustNew(t, "2081-07-17")), false)
+	assert.Equal(t, c.IsBusinessDay(mustNew(t, "2081-07-18")), true)
+
+	c.RemoveHoliday(mustNew(t, "2081-07-16"))
+	assert.Equal(t, c.IsBusinessDay(mustNew(t, "2081-07-16")), true)
+}
+
+func TestCustomWeekend(t *testing.T) {
+	var c = NewBusinessCalendar(time.Saturday, time.Sunday)
+	assert.Equal(t, c.IsBusinessDay(mustNew(t, "2081-07-17")), false)
+	assert.Equal(t, c.IsBusinessDay(mustNew(t, "2081-07-18")), false)
+	assert.Equal(t, c.IsBusinessDay(mustNew(t, "2081-07-19")), true)
+}
+
+var addedBusinessDays = []struct {
+	date     string
+	days     int
+	expected string
+}{
+	{"2081-07-14", 0, "2081-07-14"},
+	{"2081-07-14", 1, "2081-07-15"},
+	{"2081-07-14", 2, "2081-07-18"},
+	{"2081-07-14", 3, "2081-07-19"},
+	{"2081-07-17", 1, "2081-07-18"}, //starting on a weekend
+	{"2081-07-19", -3, "2081-07-14"},
+	{"2081-07-28", 2, "2081-08-02"}, //over the end of the month
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	var c = kartik2081Calendar(t)
+	for _, testCase := range addedBusinessDays {
+		t.Run(testCase.date, func(t *testing.T) {
+			result, err := c.AddBusinessDays(mustNew(t, testCase.date), testCase.days)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, formatForTest(result), testCase.expected)
+		})
+	}
+}
+
+func TestNextAndPreviousBusinessDay(t *testing.T) {
+	var c = kartik2081Calendar(t)
+	next, err := c.NextBusinessDay(mustNew(t, "2081-07-15"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, formatForTest(next), "2081-07-18")
+
+	previous, err := c.PreviousBusinessDay(mustNew(t, "2081-07-18"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, formatForTest(previous), "2081-07-15")
+
+	_, err = c.NextBusinessDay(mustNew(t, "2100-12-30"))
+	assert.Equal(t, err, ErrMissingData)
+}
+
+func TestBusinessDaysBetween(t *testing.T) {
+	var c = kartik2081Calendar(t)
+	days, err := c.BusinessDaysBetween(mustNew(t, "2081-07-14"), mustNew(t, "2081-07-19"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, days, 3)
+
+	days, err = c.BusinessDaysBetween(mustNew(t, "2081-07-19"), mustNew(t, "2081-07-14"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, days, -3)
+
+	days, err = c.BusinessDaysBetween(mustNew(t, "2081-07-01"), mustNew(t, "2081-08-01"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, days, 24) //30 days, 4 Saturdays and 2 holidays
+}
+
+var adjustments = []struct {
+	date       string
+	adjustment Adjustment
+	expected   string
+}{
+	{"2081-07-15", Following, "2081-07-15"}, //business days are not moved
+	{"2081-07-17", Unadjusted, "2081-07-17"},
+	{"2081-07-17", Following, "2081-07-18"},
+	{"2081-07-17", Preceding, "2081-07-15"},
+	{"2081-07-17", ModifiedFollowing, "2081-07-18"},
+	{"2081-07-30", Following, "2081-08-02"},
+	{"2081-07-30", ModifiedFollowing, "2081-07-29"}, //the following business day is in Mangsir
+	{"2081-08-01", Preceding, "2081-07-29"},
+	{"2081-08-01", ModifiedPreceding, "2081-08-02"}, //the preceding business day is in Kartik
+}
+
+func TestAdjust(t *testing.T) {
+	var c = kartik2081Calendar(t)
+	for _, testCase := range adjustments {
+		t.Run(testCase.date, func(t *testing.T) {
+			result, err := c.Adjust(mustNew(t, testCase.date), testCase.adjustment)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, formatForTest(result), testCase.expected)
+		})
+	}
+}
+
+func TestBusinessDaysInVendorCalendar(t *testing.T) {
+	var vendor = vendorCalendar(t)
+	var c = NewBusinessCalendar()
+	//Baisakh 2081 has 30 days in the vendor calendar and the 29th is a Saturday
+	days, err := c.BusinessDaysBetween(mustNewIn(t, vendor, "2081-01-28"), mustNewIn(t, vendor, "2081-02-03"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, days, 4)
+	next, err := c.AddBusinessDays(mustNewIn(t, vendor, "2081-01-28"), 2)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, formatForTest(next), "2081-02-01")
+	assert.Equal(t, CalendarOf(next), vendor)
+
+	_, err = c.BusinessDaysBetween(mustNew(t, "2081-01-28"), mustNewIn(t, vendor, "2081-02-03"))
+	assert.Equal(t, err, ErrMixedCalendars)
+}
@@ -0,0 +1,436 @@
+// Package caldata imports and exports the calendar data of this module and compares it with the data of other
+// libraries.
+//
+// The JSON schema lists every year with the day of Paush that is the 1st January and the lengths of the 12 months,
+// starting with Baisakh. january_in_paush can be left out if it is not known:
+//
+//	{"years": [{"year": 2081, "january_in_paush": 17, "months": [31, 31, 32, 32, 31, 30, 30, 30, 29, 30, 30, 30]}]}
+//
+// The CSV schema has the same columns, january_in_paush can be empty:
+//
+//	year,january_in_paush,baisakh,jestha,ashadh,shrawan,bhadra,ashwin,kartik,mangsir,paush,mangh,falgun,chaitra
+//	2081,17,31,31,32,32,31,30,30,30,29,30,30,30
+//
+// The layouts of other libraries only have the lengths of the months:
+//
+//	NepaliDatetime       the CSV file of the python package nepali-datetime, a header and the year with 12 lengths per row
+//	NepaliDateConverter  the data object of the javascript package nepali-date-converter, {"2081": [31, 31, ...], ...}
+package caldata
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+)
+
+// Dataset is the data of BS years, a JanuaryInPaush of 0 means it is not known
+type Dataset map[int]bsdate.YearData
+
+// Format is a layout calendar data is written in
+type Format int
+
+const (
+	// JSON is the JSON schema of this package
+	JSON Format = iota
+	// CSV is the CSV schema of this package
+	CSV
+	// NepaliDatetime is the CSV file of the python package nepali-datetime
+	NepaliDatetime
+	// NepaliDateConverter is the data object of the javascript package nepali-date-converter
+	NepaliDateConverter
+)
+
+var formatNames = map[string]Format{
+	"json":                  JSON,
+	"csv":                   CSV,
+	"nepali-datetime":       NepaliDatetime,
+	"nepali-date-converter": NepaliDateConverter,
+}
+
+// FormatNames lists the names ParseFormat understands
+func FormatNames() []string {
+	var names []string
+	for name := range formatNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseFormat returns the format with the name, e.g. "json" or "nepali-datetime"
+func ParseFormat(name string) (Format, error) {
+	format, ok := formatNames[name]
+	if !ok {
+		return 0, errors.New("unknown format " + name + ", use one of " + strings.Join(FormatNames(), ", "))
+	}
+	return format, nil
+}
+
+// Current returns the calendar data that is used by the conversions at the moment
+func Current() Dataset {
+	return Dataset(bsdate.CalendarData())
+}
+
+// Years returns the years of the dataset in ascending order
+func (d Dataset) Years() []int {
+	var years []int
+	for year := range d {
+		years = append(years, year)
+	}
+	sort.Ints(years)
+	return years
+}
+
+// Read reads calendar data in the format
+func Read(r io.Reader, format Format) (Dataset, error) {
+	switch format {
+	case JSON:
+		return readJSON(r)
+	case CSV:
+		return readCSV(r, true)
+	case NepaliDatetime:
+		return readCSV(r, false)
+	case NepaliDateConverter:
+		return readObject(r)
+	}
+	return nil, errors.New("unknown format")
+}
+
+// Write writes the dataset in the format, the years are written in ascending order.
+// Formats of other libraries cannot hold JanuaryInPaush, it is left out
+func Write(w io.Writer, data Dataset, format Format) error {
+	switch format {
+	case JSON:
+		return writeJSON(w, data)
+	case CSV:
+		return writeCSV(w, data, true)
+	case NepaliDatetime:
+		return writeCSV(w, data, false)
+	case NepaliDateConverter:
+		return writeObject(w, data)
+	}
+	return errors.New("unknown format")
+}
+
+// Apply uses the dataset for all conversions, see bsdate.UpdateCalendarData.
+// Missing values of JanuaryInPaush are taken from the current data or calculated from the year before,
+// so datasets of other libraries can be applied as well
+func Apply(data Dataset) (int64, error) {
+	years, err := complete(data)
+	if err != nil {
+		return bsdate.DataVersion(), err
+	}
+	return bsdate.UpdateCalendarData(years)
+}
+
+// NewCalendar creates a calendar with the dataset, e.g. to compare conversions of another library with the ones of
+// this module. Missing values of JanuaryInPaush are filled in like by Apply
+func NewCalendar(name string, data Dataset) (*bsdate.Calendar, error) {
+	years, err := complete(data)
+	if err != nil {
+		return nil, err
+	}
+	return bsdate.NewCalendar(name, years)
+}
+
+// complete fills in the missing values of JanuaryInPaush
+func complete(data Dataset) (Dataset, error) {
+	var current = Current()
+	var years = Dataset{}
+	for _, year := range data.Years() {
+		var yearData = data[year]
+		if yearData.JanuaryInPaush == 0 {
+			if known, ok := current[year]; ok && known.MonthLengths == yearData.MonthLengths {
+				yearData.JanuaryInPaush = known.JanuaryInPaush
+			} else if previous, ok := years[year-1]; ok {
+				yearData.JanuaryInPaush = januaryAfter(previous, yearData, year-1)
+			} else if previous, ok := current[year-1]; ok {
+				yearData.JanuaryInPaush = januaryAfter(previous, yearData, year-1)
+			} else {
+				return nil, errors.New("year " + strconv.Itoa(year) + ": the 1st January in Paush is not known")
+			}
+		}
+		years[year] = yearData
+	}
+	return years, nil
+}
+
+// januaryAfter calculates the day of Paush of the 1st January in the year after previousYear
+func januaryAfter(previous, next bsdate.YearData, previousYear int) int {
+	var gregorianYear = previousYear - 56
+	var days = time.Date(gregorianYear, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	days -= previous.MonthLengths[8] - previous.JanuaryInPaush + 1
+	for month := 9; month < 12; month++ {
+		days -= previous.MonthLengths[month]
+	}
+	for month := 0; month < 8; month++ {
+		days -= next.MonthLengths[month]
+	}
+	return days + 1
+}
+
+// Difference describes a year in which two datasets disagree
+type Difference struct {
+	Year int
+	// Base and Other are the data of the year in both datasets
+	Base, Other bsdate.YearData
+	// MissingInBase and MissingInOther tell that only one of the datasets has the year
+	MissingInBase, MissingInOther bool
+	// Months are the months (1-12) with different lengths
+	Months []int
+	// January is true if both datasets know the 1st January in Paush and disagree
+	January bool
+}
+
+func (d Difference) String() string {
+	var year = strconv.Itoa(d.Year)
+	switch {
+	case d.MissingInBase:
+		return year + ": only in the other dataset"
+	case d.MissingInOther:
+		return year + ": only in the base dataset"
+	}
+	var parts []string
+	for _, month := range d.Months {
+		parts = append(parts, bsdate.MonthNames[month-1]+" "+strconv.Itoa(d.Base.MonthLengths[month-1])+
+			" != "+strconv.Itoa(d.Other.MonthLengths[month-1]))
+	}
+	if d.January {
+		parts = append(parts, "1st January in Paush "+strconv.Itoa(d.Base.JanuaryInPaush)+
+			" != "+strconv.Itoa(d.Other.JanuaryInPaush))
+	}
+	return year + ": " + strings.Join(parts, ", ")
+}
+
+// Diff compares two datasets year by year, e.g. the data of another library with Current().
+// Years in which both agree are left out
+func Diff(base, other Dataset) []Difference {
+	var years = Dataset{}
+	for year := range base {
+		years[year] = bsdate.YearData{}
+	}
+	for year := range other {
+		years[year] = bsdate.YearData{}
+	}
+	var differences []Difference
+	for _, year := range years.Years() {
+		baseData, inBase := base[year]
+		otherData, inOther := other[year]
+		var difference = Difference{
+			Year: year, Base: baseData, Other: otherData, MissingInBase: !inBase, MissingInOther: !inOther,
+		}
+		if inBase && inOther {
+			for month := 1; month <= 12; month++ {
+				if baseData.MonthLengths[month-1] != otherData.MonthLengths[month-1] {
+					difference.Months = append(difference.Months, month)
+				}
+			}
+			difference.January = baseData.JanuaryInPaush != 0 && otherData.JanuaryInPaush != 0 &&
+				baseData.JanuaryInPaush != otherData.JanuaryInPaush
+			if len(difference.Months) == 0 && !difference.January {
+				continue
+			}
+		}
+		differences = append(differences, difference)
+	}
+	return differences
+}
+
+// jsonYear is a year in the JSON schema
+type jsonYear struct {
+	Year           int     `json:"year"`
+	JanuaryInPaush int     `json:"january_in_paush,omitempty"`
+	Months         [12]int `json:"months"`
+}
+
+type jsonDataset struct {
+	Years []jsonYear `json:"years"`
+}
+
+func readJSON(r io.Reader) (Dataset, error) {
+	var decoded jsonDataset
+	if err := json.NewDecoder(r).Decode(&decoded); err != nil {
+		return nil, err
+	}
+	var data = Dataset{}
+	for _, year := range decoded.Years {
+		if err := data.add(year.Year, year.JanuaryInPaush, year.Months[:]); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+func writeJSON(w io.Writer, data Dataset) error {
+	var encoded = jsonDataset{Years: []jsonYear{}}
+	for _, year := range data.Years() {
+		encoded.Years = append(encoded.Years, jsonYear{year, data[year].JanuaryInPaush, data[year].MonthLengths})
+	}
+	var encoder = json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(encoded)
+}
+
+// add checks the values of a year and adds it
+func (d Dataset) add(year, januaryInPaush int, months []int) error {
+	var prefix = "year " + strconv.Itoa(year) + ": "
+	if _, exists := d[year]; exists {
+		return errors.New(prefix + "is listed twice")
+	}
+	if len(months) != 12 {
+		return errors.New(prefix + "has " + strconv.Itoa(len(months)) + " months instead of 12")
+	}
+	var yearData = bsdate.YearData{JanuaryInPaush: januaryInPaush}
+	for i, days := range months {
+		if days <= 0 {
+			return errors.New(prefix + bsdate.MonthNames[i] + " has no days")
+		}
+		yearData.MonthLengths[i] = days
+	}
+	d[year] = yearData
+	return nil
+}
+
+func csvHeader(withJanuary bool) []string {
+	var header = []string{"year"}
+	if withJanuary {
+		header = append(header, "january_in_paush")
+	}
+	for _, name := range bsdate.MonthNames {
+		header = append(header, strings.ToLower(name))
+	}
+	return header
+}
+
+// readCSV reads the CSV schema, or the layout of nepali-datetime that has no january_in_paush column.
+// The first row is the header
+func readCSV(r io.Reader, withJanuary bool) (Dataset, error) {
+	var reader = csv.NewReader(r)
+	reader.FieldsPerRecord = 13
+	if withJanuary {
+		reader.FieldsPerRecord = 14
+	}
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("the header is missing")
+	}
+	var data = Dataset{}
+	for row, record := range records[1:] {
+		var numbers = make([]int, len(record))
+		for i, field := range record {
+			if field == "" && withJanuary && i == 1 {
+				continue
+			}
+			if numbers[i], err = strconv.Atoi(strings.TrimSpace(field)); err != nil {
+				return nil, errors.New("row " + strconv.Itoa(row+2) + ": " + field + " is not a number")
+			}
+		}
+		var january, months = 0, numbers[1:]
+		if withJanuary {
+			january, months = numbers[1], numbers[2:]
+		}
+		if err := data.add(numbers[0], january, months); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+func writeCSV(w io.Writer, data Dataset, withJanuary bool) error {
+	var writer = csv.NewWriter(w)
+	if err := writer.Write(csvHeader(withJanuary)); err != nil {
+		return err
+	}
+	for _, year := range data.Years() {
+		var record = []string{strconv.Itoa(year)}
+		if withJanuary {
+			var january = ""
+			if data[year].JanuaryInPaush != 0 {
+				january = strconv.Itoa(data[year].JanuaryInPaush)
+			}
+			record = append(record, january)
+		}
+		for _, days := range data[year].MonthLengths {
+			record = append(record, strconv.Itoa(days))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// objectYear matches a year of a javascript or JSON object like 2081: [31, 31, ...], the key can be quoted
+var objectYear = regexp.MustCompile(`["']?(\d+)["']?\s*:\s*\[([\d\s,]*)\]`)
+
+// readObject reads the data object of nepali-date-converter. It is read leniently, so the javascript source the
+// object is defined in can be read as well. A 13th value is taken as the length of the year and has to match
+func readObject(r io.Reader) (Dataset, error) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var data = Dataset{}
+	for _, match := range objectYear.FindAllStringSubmatch(string(content), -1) {
+		var year, _ = strconv.Atoi(match[1])
+		var months []int
+		for _, field := range strings.Split(match[2], ",") {
+			if field = strings.TrimSpace(field); field == "" {
+				continue
+			}
+			days, _ := strconv.Atoi(field)
+			months = append(months, days)
+		}
+		if len(months) == 13 {
+			var length = 0
+			for _, days := range months[:12] {
+				length += days
+			}
+			if length != months[12] {
+				return nil, errors.New("year " + match[1] + ": the months have " + strconv.Itoa(length) +
+					" days, but the year has " + strconv.Itoa(months[12]))
+			}
+			months = months[:12]
+		}
+		if err := data.add(year, 0, months); err != nil {
+			return nil, err
+		}
+	}
+	if len(data) == 0 {
+		return nil, errors.New("there are no years in the data")
+	}
+	return data, nil
+}
+
+func writeObject(w io.Writer, data Dataset) error {
+	var output strings.Builder
+	output.WriteString("{\n")
+	for i, year := range data.Years() {
+		var months []string
+		for _, days := range data[year].MonthLengths {
+			months = append(months, strconv.Itoa(days))
+		}
+		output.WriteString("  \"" + strconv.Itoa(year) + "\": [" + strings.Join(months, ", ") + "]")
+		if i < len(data)-1 {
+			output.WriteString(",")
+		}
+		output.WriteString("\n")
+	}
+	output.WriteString("}\n")
+	_, err := io.WriteString(w, output.String())
+	return err
+}
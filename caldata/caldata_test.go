@@ -0,0 +1,190 @@
+package caldata
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+	"github.com/magiconair/properties/assert"
+)
+
+var sample = Dataset{
+	2081: {JanuaryInPaush: 17, MonthLengths: [12]int{31, 31, 32, 32, 31, 30, 30, 30, 29, 30, 30, 30}},
+	2082: {MonthLengths: [12]int{31, 32, 31, 32, 31, 30, 30, 30, 29, 30, 30, 30}},
+}
+
+func TestWrite(t *testing.T) {
+	var tests = []struct {
+		format   Format
+		expected string
+	}{
+		{JSON, "{\n  \"years\": [\n" +
+			"    {\n      \"year\": 2081,\n      \"january_in_paush\": 17,\n      \"months\": [\n        31,\n        31,\n        32,\n" +
+			"        32,\n        31,\n        30,\n        30,\n        30,\n        29,\n        30,\n        30,\n        30\n      ]\n    },\n" +
+			"    {\n      \"year\": 2082,\n      \"months\": [\n        31,\n        32,\n        31,\n" +
+			"        32,\n        31,\n        30,\n        30,\n        30,\n        29,\n        30,\n        30,\n        30\n      ]\n    }\n" +
+			"  ]\n}\n"},
+		{CSV, "year,january_in_paush,baisakh,jestha,ashadh,shrawan,bhadra,ashwin,kartik,mangsir,paush,mangh,falgun,chaitra\n" +
+			"2081,17,31,31,32,32,31,30,30,30,29,30,30,30\n" +
+			"2082,,31,32,31,32,31,30,30,30,29,30,30,30\n"},
+		{NepaliDatetime, "year,baisakh,jestha,ashadh,shrawan,bhadra,ashwin,kartik,mangsir,paush,mangh,falgun,chaitra\n" +
+			"2081,31,31,32,32,31,30,30,30,29,30,30,30\n" +
+			"2082,31,32,31,32,31,30,30,30,29,30,30,30\n"},
+		{NepaliDateConverter, "{\n" +
+			"  \"2081\": [31, 31, 32, 32, 31, 30, 30, 30, 29, 30, 30, 30],\n" +
+			"  \"2082\": [31, 32, 31, 32, 31, 30, 30, 30, 29, 30, 30, 30]\n" +
+			"}\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.expected[:10], func(t *testing.T) {
+			var output bytes.Buffer
+			assert.Equal(t, Write(&output, sample, test.format), nil)
+			assert.Equal(t, output.String(), test.expected)
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	var withoutJanuary = Dataset{}
+	for year, data := range Current() {
+		data.JanuaryInPaush = 0
+		withoutJanuary[year] = data
+	}
+	var tests = []struct {
+		name     string
+		format   Format
+		expected Dataset
+	}{
+		{"json", JSON, Current()},
+		{"csv", CSV, Current()},
+		{"nepali-datetime", NepaliDatetime, withoutJanuary},
+		{"nepali-date-converter", NepaliDateConverter, withoutJanuary},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var output bytes.Buffer
+			assert.Equal(t, Write(&output, Current(), test.format), nil)
+			data, err := Read(&output, test.format)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, data, test.expected)
+		})
+	}
+}
+
+func TestReadOtherLibraries(t *testing.T) {
+	var csvFile = "Year,Baishakh,Jestha,Ashad,Shrawan,Bhadra,Ashwin,Kartik,Mangshir,Poush,Magh,Falgun,Chaitra\n" +
+		"2081, 31, 31, 32, 32, 31, 30, 30, 30, 29, 30, 30, 30\n"
+	data, err := Read(strings.NewReader(csvFile), NepaliDatetime)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, data[2081].MonthLengths, sample[2081].MonthLengths)
+
+	//the javascript source, the 13th value is the length of the year
+	var source = "const calendarData = {\n" +
+		"  2081: [31, 31, 32, 32, 31, 30, 30, 30, 29, 30, 30, 30, 366],\n" +
+		"  2082: [31, 32, 31, 32, 31, 30, 30, 30, 29, 30, 30, 30],\n" +
+		"};\nexport default calendarData;\n"
+	data, err = Read(strings.NewReader(source), NepaliDateConverter)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, data, Dataset{2081: {MonthLengths: sample[2081].MonthLengths}, 2082: sample[2082]})
+}
+
+func TestReadInvalid(t *testing.T) {
+	var tests = []struct {
+		format   Format
+		input    string
+		expected string
+	}{
+		{JSON, `{"years": [{"year": 2081, "months": [31, 31, 32, 32, 31, 30, 30, 30, 29, 30, 30]}]}`, "year 2081: Chaitra has no days"},
+		{JSON, `{"years": [{"year": 2081, "months": [31, 31, 32, 32, 31, 30, 30, 30, 29, 30, 30, 30]},` +
+			`{"year": 2081, "months": [31, 31, 32, 32, 31, 30, 30, 30, 29, 30, 30, 30]}]}`, "year 2081: is listed twice"},
+		{CSV, "", "the header is missing"},
+		{CSV, "year,january_in_paush,1,2,3,4,5,6,7,8,9,10,11,12\n2081,x,31,31,32,32,31,30,30,30,29,30,30,30\n",
+			"row 2: x is not a number"},
+		{NepaliDateConverter, "{2081: [31, 31, 32, 32, 31, 30, 30, 30, 29, 30, 30, 30, 365]}",
+			"year 2081: the months have 366 days, but the year has 365"},
+		{NepaliDateConverter, "{2081: [31, 31, 32]}", "year 2081: has 3 months instead of 12"},
+		{NepaliDateConverter, "{}", "there are no years in the data"},
+	}
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			_, err := Read(strings.NewReader(test.input), test.format)
+			assert.Equal(t, err.Error(), test.expected)
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("nepali-datetime")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, format, NepaliDatetime)
+	_, err = ParseFormat("xml")
+	assert.Equal(t, err.Error(), "unknown format xml, use one of csv, json, nepali-date-converter, nepali-datetime")
+}
+
+func TestDiff(t *testing.T) {
+	var other = Dataset{}
+	for year, data := range Current() {
+		other[year] = data
+	}
+	delete(other, 1970)
+	other[2101] = sample[2082]
+	var changed = other[2081]
+	changed.MonthLengths[0], changed.MonthLengths[1] = 30, 32
+	changed.JanuaryInPaush = 16
+	other[2081] = changed
+	//an unknown 1st January is no difference
+	var unknown = other[2082]
+	unknown.JanuaryInPaush = 0
+	other[2082] = unknown
+
+	var differences []string
+	for _, difference := range Diff(Current(), other) {
+		differences = append(differences, difference.String())
+	}
+	assert.Equal(t, differences, []string{
+		"1970: only in the base dataset",
+		"2081: Baisakh 31 != 30, Jestha 31 != 32, 1st January in Paush 17 != 16",
+		"2101: only in the other dataset",
+	})
+	assert.Equal(t, len(Diff(Current(), Current())), 0)
+}
+
+func TestApply(t *testing.T) {
+	defer bsdate.ResetCalendarData()
+	var version = bsdate.DataVersion()
+	//2070 is known, 2101 follows 2100 of the current data
+	var data = Dataset{
+		2070: {MonthLengths: Current()[2070].MonthLengths},
+		2101: {MonthLengths: [12]int{31, 31, 32, 31, 31, 31, 30, 29, 30, 29, 30, 30}},
+	}
+	newVersion, err := Apply(data)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, newVersion, version+1)
+	year, ok := bsdate.LookupYear(2101)
+	assert.Equal(t, ok, true)
+	assert.Equal(t, year.JanuaryInPaush, 18)
+	d, err := bsdate.NewFromGregorian(13, 4, 2044)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, bsdate.Format(d, bsdate.ISOLayout), "2101-01-01")
+
+	_, err = Apply(Dataset{2200: sample[2082]})
+	assert.Equal(t, err.Error(), "year 2200: the 1st January in Paush is not known")
+}
+
+func TestNewCalendar(t *testing.T) {
+	var data = Current()
+	var vendorYear = data[2081]
+	vendorYear.MonthLengths[0], vendorYear.MonthLengths[1] = 30, 32
+	vendorYear.JanuaryInPaush = 0
+	data[2081] = vendorYear
+	vendor, err := NewCalendar("vendor", data)
+	assert.Equal(t, err, nil)
+	year, _ := vendor.LookupYear(2081)
+	assert.Equal(t, year.JanuaryInPaush, januaryAfter(data[2080], vendorYear, 2080))
+
+	d, err := vendor.FromGregorian(14, 5, 2024)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, bsdate.Format(d, bsdate.ISOLayout), "2081-02-02")
+	assert.Equal(t, bsdate.CalendarOf(d), vendor)
+}
@@ -0,0 +1,144 @@
+package bsdate
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrMixedCalendars is returned if dates of different calendars are used together
+var ErrMixedCalendars = errors.New("the dates belong to different calendars")
+
+// Calendar converts dates with its own calendar data, so different datasets can be used side by side.
+// Dates remember the calendar they were created by. The package level functions use the default calendar.
+// A Calendar is safe for concurrent use
+type Calendar struct {
+	name string
+	//initial is the data the calendar was created with, Reset goes back to it
+	initial *calendarSnapshot
+	//value holds the current *calendarSnapshot, it is empty till the data is updated the first time
+	value atomic.Value
+	//updateMutex makes sure updates do not overwrite each other, reading needs no lock
+	updateMutex sync.Mutex
+	//model holds the statistics for approximated dates, see getModel
+	modelMutex sync.Mutex
+	model      *calendarModel
+}
+
+var defaultCalendar = newCalendar("default", builtinSnapshot)
+
+func newCalendar(name string, initial *calendarSnapshot) *Calendar {
+	return &Calendar{name: name, initial: initial}
+}
+
+// date creates a date of the calendar without checking it
+func (c *Calendar) date(day, month, year int) date {
+	var d = date{Day: day, Month: month, Year: year}
+	if c != defaultCalendar {
+		d.calendar = c
+	}
+	return d
+}
+
+// DefaultCalendar returns the calendar with the data that is built into this module,
+// it is used by the package level functions like New and NewFromGregorian
+func DefaultCalendar() *Calendar {
+	return defaultCalendar
+}
+
+// NewCalendar creates a calendar with its own data, e.g. the dataset of a vendor, see YearData.
+// The years have to follow each other without a gap and every month needs 29 to 32 days.
+// Unlike Update the lengths of the years and the 1st January of following years are not checked,
+// as many published datasets (including the one of this module) do not agree with them in every year
+func NewCalendar(name string, years map[int]YearData) (*Calendar, error) {
+	if len(years) == 0 {
+		return nil, errors.New("calendar " + name + " has no years")
+	}
+	var sorted = sortedYears(years)
+	var table = calendarTable{}
+	for _, year := range sorted {
+		if err := checkRow(year, years[year]); err != nil {
+			return nil, errors.New("calendar " + name + ": " + err.Error())
+		}
+		table[year] = fromYearData(years[year])
+	}
+	if sorted[len(sorted)-1]-sorted[0] != len(sorted)-1 {
+		return nil, errors.New("calendar " + name + ": the years have to follow each other without a gap")
+	}
+	return newCalendar(name, newSnapshot(1, table)), nil
+}
+
+// Name returns the name the calendar was created with, the default calendar is called "default"
+func (c *Calendar) Name() string {
+	return c.name
+}
+
+// CalendarOf returns the calendar d belongs to, dates that are not created by this package belong to the default calendar
+func CalendarOf(d Date) *Calendar {
+	if d, ok := d.(date); ok {
+		return d.getCalendar()
+	}
+	return defaultCalendar
+}
+
+// AddDays returns the date that is the given amount of days after (or before, for negative values) d
+func (c *Calendar) AddDays(d Date, days int) (Date, error) {
+	if CalendarOf(d) != c {
+		return nil, ErrMixedCalendars
+	}
+	var data = c.snapshot()
+	if !data.contains(d) {
+		return nil, ErrMissingData
+	}
+	return c.fromOrdinal(data, data.toOrdinal(d)+days)
+}
+
+// DaysBetween returns the amount of days from "from" till "to", negative if "to" is before "from"
+func (c *Calendar) DaysBetween(from, to Date) (int, error) {
+	if CalendarOf(from) != c || CalendarOf(to) != c {
+		return 0, ErrMixedCalendars
+	}
+	var data = c.snapshot()
+	if !data.contains(from) || !data.contains(to) {
+		return 0, ErrMissingData
+	}
+	return data.toOrdinal(to) - data.toOrdinal(from), nil
+}
+
+// Compare returns -1 if a is before b, 0 if both are the same day and +1 if a is after b
+func (c *Calendar) Compare(a, b Date) (int, error) {
+	diff, err := c.DaysBetween(b, a)
+	switch {
+	case err != nil:
+		return 0, err
+	case diff < 0:
+		return -1, nil
+	case diff > 0:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Weekday returns the day of the week of d, also for the first days of the data that cannot be converted to
+// gregorian. ErrMissingData is returned if d is not a day of the calendar data
+func (c *Calendar) Weekday(d Date) (time.Weekday, error) {
+	if CalendarOf(d) != c {
+		return time.Sunday, ErrMixedCalendars
+	}
+	var data = c.snapshot()
+	if !data.contains(d) {
+		return time.Sunday, ErrMissingData
+	}
+	//the 1st January is the JanuaryInPaush-th of Paush, so the weekday of every 1st Paush is known.
+	//Like GetGregorianDate the days are counted from the last Paush before d, the first months of the data
+	//are counted back from the Paush after them
+	var year = d.GetYear()
+	if _, ok := data.years[year-1]; ok && d.GetMonth() < 9 {
+		year--
+	}
+	var january = time.Date(year-56, time.January, 1, 0, 0, 0, 0, time.UTC)
+	var paush = january.AddDate(0, 0, 1-data.years[year][0])
+	var days = data.toOrdinal(d) - data.toOrdinal(c.date(1, 9, year))
+	return time.Weekday((int(paush.Weekday()) + days%7 + 7) % 7), nil
+}
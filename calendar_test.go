@@ -0,0 +1,204 @@
+package bsdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+)
+
+// vendorCalendar has the data of this module, but a Baisakh 2081 with 30 and a Jestha with 32 days
+func vendorCalendar(t *testing.T) *Calendar {
+	var data = CalendarData()
+	var year = data[2081]
+	year.MonthLengths[0], year.MonthLengths[1] = 30, 32
+	data[2081] = year
+	calendar, err := NewCalendar("vendor", data)
+	assert.Equal(t, err, nil)
+	return calendar
+}
+
+func TestCalendarSideBySide(t *testing.T) {
+	var vendor = vendorCalendar(t)
+	assert.Equal(t, vendor.Name(), "vendor")
+	assert.Equal(t, DefaultCalendar().Name(), "default")
+
+	official, err := New(1, 2, 2081)
+	assert.Equal(t, err, nil)
+	vendorDate, err := vendor.New(1, 2, 2081)
+	assert.Equal(t, err, nil)
+	officialGregorian, _ := official.GetGregorianDate()
+	vendorGregorian, _ := vendorDate.GetGregorianDate()
+	assert.Equal(t, officialGregorian.Format("2006-01-02"), "2024-05-14")
+	assert.Equal(t, vendorGregorian.Format("2006-01-02"), "2024-05-13")
+
+	d, err := vendor.FromGregorian(14, 5, 2024)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, formatForTest(d), "2081-02-02")
+	days, err := vendor.DaysInMonth(2081, 1)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, days, 30)
+	_, err = vendor.New(31, 1, 2081)
+	assert.Equal(t, err, ErrInvalidDate)
+
+	approx, err := vendor.ApproxToGregorian(1, 2, 2081)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, approx.Gregorian, vendorGregorian)
+}
+
+func TestCalendarOf(t *testing.T) {
+	var vendor = vendorCalendar(t)
+	official, _ := New(30, 1, 2081)
+	vendorDate, _ := vendor.New(30, 1, 2081)
+	assert.Equal(t, CalendarOf(official), DefaultCalendar())
+	assert.Equal(t, CalendarOf(vendorDate), vendor)
+
+	//dates of the default calendar are the same, no matter how they are created
+	viaDefault, _ := DefaultCalendar().New(30, 1, 2081)
+	assert.Equal(t, viaDefault, official)
+
+	next, err := AddDays(vendorDate, 1)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, formatForTest(next), "2081-02-01")
+	assert.Equal(t, CalendarOf(next), vendor)
+	next, err = AddDays(official, 1)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, formatForTest(next), "2081-01-31")
+
+	vendorNext, _ := AddDays(vendorDate, 1)
+	r, err := NewClosedRange(vendorDate, vendorNext)
+	assert.Equal(t, err, nil)
+	var dates []string
+	for it := r.Iterate(); it.Next(); {
+		assert.Equal(t, CalendarOf(it.Date()), vendor)
+		dates = append(dates, formatForTest(it.Date()))
+	}
+	assert.Equal(t, dates, []string{"2081-01-30", "2081-02-01"})
+}
+
+func TestCalendarMixing(t *testing.T) {
+	var vendor = vendorCalendar(t)
+	official, _ := New(1, 1, 2081)
+	vendorDate, _ := vendor.New(1, 1, 2081)
+
+	_, err := vendor.DaysBetween(official, vendorDate)
+	assert.Equal(t, err, ErrMixedCalendars)
+	_, err = vendor.Compare(vendorDate, official)
+	assert.Equal(t, err, ErrMixedCalendars)
+	_, err = vendor.AddDays(official, 1)
+	assert.Equal(t, err, ErrMixedCalendars)
+	days, err := vendor.DaysBetween(vendorDate, vendorDate)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, days, 0)
+	_, err = DaysBetween(official, vendorDate)
+	assert.Equal(t, err, ErrMixedCalendars)
+	_, err = Compare(official, vendorDate)
+	assert.Equal(t, err, ErrMixedCalendars)
+	order, err := Compare(official, mustNew(t, "2081-02-02"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, order, -1)
+}
+
+// a calendar does not need the years of the default calendar for counting days
+func TestCalendarWithFewYears(t *testing.T) {
+	var years = map[int]YearData{}
+	for year, data := range CalendarData() {
+		if year >= 2070 && year <= 2090 {
+			years[year] = data
+		}
+	}
+	calendar, err := NewCalendar("few years", years)
+	assert.Equal(t, err, nil)
+
+	var start = mustNewIn(t, calendar, "2070-01-01")
+	next, err := calendar.AddDays(start, 40)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, formatForTest(next), "2070-02-10")
+	days, err := calendar.DaysBetween(start, mustNewIn(t, calendar, "2090-12-30"))
+	assert.Equal(t, err, nil)
+	officialDays, err := DaysBetween(mustNew(t, "2070-01-01"), mustNew(t, "2090-12-30"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, days, officialDays)
+	_, err = calendar.AddDays(start, -1)
+	assert.Equal(t, err, ErrMissingData)
+
+	occurrences, err := Rule{Freq: Daily, Interval: 40}.Expand(start, 2)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, formatForTest(occurrences[1]), "2070-02-10")
+}
+
+func TestCalendarUpdate(t *testing.T) {
+	var vendor = vendorCalendar(t)
+	var defaultVersion = DataVersion()
+	assert.Equal(t, vendor.Version(), int64(1))
+
+	version, err := vendor.Update(map[int]YearData{2101: year2101})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, version, int64(2))
+	_, ok := vendor.LookupYear(2101)
+	assert.Equal(t, ok, true)
+	_, ok = LookupYear(2101)
+	assert.Equal(t, ok, false)
+	assert.Equal(t, DataVersion(), defaultVersion)
+
+	assert.Equal(t, vendor.Reset(), int64(3))
+	year, _ := vendor.LookupYear(2081)
+	assert.Equal(t, year.MonthLengths[0], 30)
+	_, ok = vendor.LookupYear(2101)
+	assert.Equal(t, ok, false)
+}
+
+func TestNewCalendarInvalid(t *testing.T) {
+	var shortMonth = year2101
+	shortMonth.MonthLengths[3] = 28
+	var tests = []struct {
+		years    map[int]YearData
+		expected string
+	}{
+		{map[int]YearData{}, "calendar test has no years"},
+		{map[int]YearData{2101: year2101, 2103: year2101}, "calendar test: the years have to follow each other without a gap"},
+		{map[int]YearData{2101: shortMonth}, "calendar test: year 2101: Shrawan has 28 days, a month has 29 to 32 days"},
+	}
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			_, err := NewCalendar("test", test.years)
+			assert.Equal(t, err.Error(), test.expected)
+		})
+	}
+}
+
+func TestCalendarWeekday(t *testing.T) {
+	//the weekday of every date that can be converted is the one of its gregorian date
+	for year := 1970; year <= 2100; year++ {
+		for month := 1; month <= 12; month++ {
+			days, err := DaysInMonth(year, month)
+			assert.Equal(t, err, nil)
+			for day := 1; day <= days; day++ {
+				d, err := New(day, month, year)
+				assert.Equal(t, err, nil)
+				if gregorianDate, err := d.GetGregorianDate(); err == nil && Weekday(d) != gregorianDate.Weekday() {
+					t.Errorf("%s is a %s, not a %s", formatForTest(d), gregorianDate.Weekday(), Weekday(d))
+				}
+			}
+		}
+	}
+	//1970-01-01 was the 13th April 1913, it cannot be converted by GetGregorianDate
+	assert.Equal(t, Weekday(mustNew(t, "1970-01-01")), time.Sunday)
+	assert.Equal(t, Weekday(mustNew(t, "1970-01-02")), time.Monday)
+
+	//no day of a calendar with a single year can be counted from an earlier Paush
+	calendar, err := NewCalendar("single year", map[int]YearData{2100: CalendarData()[2100]})
+	assert.Equal(t, err, nil)
+	weekday, err := calendar.Weekday(mustNewIn(t, calendar, "2100-01-01"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, weekday, Weekday(mustNew(t, "2100-01-01")))
+
+	_, err = calendar.Weekday(mustNew(t, "2100-01-01"))
+	assert.Equal(t, err, ErrMixedCalendars)
+	_, err = calendar.Update(map[int]YearData{2101: year2101})
+	assert.Equal(t, err, nil)
+	var added = mustNewIn(t, calendar, "2101-01-01")
+	calendar.Reset()
+	_, err = calendar.Weekday(added)
+	assert.Equal(t, err, ErrMissingData)
+}
@@ -0,0 +1,114 @@
+// Command capi is a C library of the conversions of this module. Build it with cgo:
+//
+//	go build -buildmode=c-shared -o libbsdate.so ./capi
+//
+// This also writes the header libbsdate.h. All functions return one of the BSDATE_* codes defined in it,
+// BSDATE_OK (0) means success. BSErrorMessage describes a code.
+//
+// Memory: the library never allocates memory the caller has to free and keeps no pointer after a function returns.
+// Strings are written into buffers that belong to the caller. If a buffer is too small nothing is written,
+// the function returns BSDATE_BUFFER_TOO_SMALL and sets the size that is needed, including the terminating NUL.
+// The messages returned by BSErrorMessage belong to the library, they stay valid and must not be freed.
+//
+// All functions are safe to call from several threads at the same time.
+package main
+
+import (
+	"time"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+)
+
+// The codes have to match the BSDATE_* defines in exports.go
+const (
+	codeOK = iota
+	codeInvalidDate
+	codeInvalidMonthType
+	codeMissingData
+	codeBufferTooSmall
+	codeInvalidArgument
+	codeUnknown
+)
+
+var errorMessages = map[int]string{
+	codeOK:               "no error",
+	codeInvalidDate:      bsdate.ErrInvalidDate.Error(),
+	codeInvalidMonthType: bsdate.ErrInvalidMonthType.Error(),
+	codeMissingData:      bsdate.ErrMissingData.Error(),
+	codeBufferTooSmall:   "the buffer is too small",
+	codeInvalidArgument:  "a pointer argument is NULL",
+	codeUnknown:          "unknown error",
+}
+
+// main is required by -buildmode=c-shared, it is never called
+func main() {}
+
+// errorCode maps the errors of the bsdate package to their codes
+func errorCode(err error) int {
+	switch err {
+	case nil:
+		return codeOK
+	case bsdate.ErrInvalidDate:
+		return codeInvalidDate
+	case bsdate.ErrInvalidMonthType:
+		return codeInvalidMonthType
+	case bsdate.ErrMissingData:
+		return codeMissingData
+	}
+	return codeUnknown
+}
+
+func toGregorian(year, month, day int) (time.Time, int) {
+	d, err := bsdate.New(day, month, year)
+	if err != nil {
+		return time.Time{}, errorCode(err)
+	}
+	gregorianDate, err := d.GetGregorianDate()
+	return gregorianDate, errorCode(err)
+}
+
+func toBS(year, month, day int) (bsdate.Date, int) {
+	//time.Date normalizes dates like February 30, so they would not be noticed otherwise
+	var gregorianDate = time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if gregorianDate.Year() != year || int(gregorianDate.Month()) != month || gregorianDate.Day() != day {
+		return nil, codeInvalidDate
+	}
+	d, err := bsdate.NewFromGregorian(day, month, year)
+	return d, errorCode(err)
+}
+
+func validate(year, month, day int) int {
+	_, err := bsdate.New(day, month, year)
+	return errorCode(err)
+}
+
+func format(year, month, day int, layout string) (string, int) {
+	d, err := bsdate.New(day, month, year)
+	if err != nil {
+		return "", errorCode(err)
+	}
+	return bsdate.Format(d, layout), codeOK
+}
+
+// maxBufferSize is the most bytes of a C buffer that are turned into a Go slice
+const maxBufferSize = 1 << 30
+
+// usableSize returns how many bytes of a C buffer with room for size bytes copyString can use for s.
+// Only the bytes that are written are needed, so a buffer that is larger than s is cut to fit s
+func usableSize(size uint64, s string) uint64 {
+	if needed := uint64(len(s)) + 1; size > needed {
+		return needed
+	}
+	return size
+}
+
+// copyString writes s with a terminating NUL into buffer, needed is the size the buffer must have
+func copyString(buffer []byte, s string) (needed int, code int) {
+	needed = len(s) + 1
+	if len(buffer) < needed {
+		return needed, codeBufferTooSmall
+	}
+	copy(buffer, s)
+	buffer[len(s)] = 0
+	return needed, codeOK
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+)
+
+func TestErrorCode(t *testing.T) {
+	assert.Equal(t, errorCode(nil), codeOK)
+	assert.Equal(t, errorCode(bsdate.ErrInvalidDate), codeInvalidDate)
+	assert.Equal(t, errorCode(bsdate.ErrInvalidMonthType), codeInvalidMonthType)
+	assert.Equal(t, errorCode(bsdate.ErrMissingData), codeMissingData)
+	assert.Equal(t, errorCode(errors.New("something else")), codeUnknown)
+	for code := codeOK; code <= codeUnknown; code++ {
+		assert.Equal(t, errorMessages[code] != "", true)
+	}
+}
+
+func TestToGregorian(t *testing.T) {
+	gregorianDate, code := toGregorian(2081, 3, 32)
+	assert.Equal(t, code, codeOK)
+	assert.Equal(t, gregorianDate.Format("2006-01-02"), "2024-07-15")
+
+	_, code = toGregorian(2081, 1, 32)
+	assert.Equal(t, code, codeInvalidDate)
+}
+
+func TestToBS(t *testing.T) {
+	d, code := toBS(2024, 7, 16)
+	assert.Equal(t, code, codeOK)
+	assert.Equal(t, []int{d.GetYear(), d.GetMonth(), d.GetDay()}, []int{2081, 4, 1})
+
+	var tests = []struct {
+		name                   string
+		year, month, day, code int
+	}{
+		{"30th February", 2024, 2, 30, codeInvalidDate},
+		{"13th month", 2024, 13, 1, codeInvalidDate},
+		{"before the data", 1800, 1, 1, codeMissingData},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, code := toBS(test.year, test.month, test.day)
+			assert.Equal(t, code, test.code)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert.Equal(t, validate(2081, 3, 32), codeOK)
+	assert.Equal(t, validate(2081, 13, 1), codeInvalidDate)
+}
+
+func TestFormat(t *testing.T) {
+	formatted, code := format(2081, 2, 5, "DD MMMM YYYY")
+	assert.Equal(t, code, codeOK)
+	assert.Equal(t, formatted, "05 Jestha 2081")
+
+	_, code = format(2081, 2, 33, "YYYY")
+	assert.Equal(t, code, codeInvalidDate)
+}
+
+func TestUsableSize(t *testing.T) {
+	assert.Equal(t, usableSize(0, "2081"), uint64(0))
+	assert.Equal(t, usableSize(4, "2081"), uint64(4))
+	assert.Equal(t, usableSize(5, "2081"), uint64(5))
+	assert.Equal(t, usableSize(1<<40, "2081"), uint64(5))
+}
+
+func TestCopyString(t *testing.T) {
+	var buffer = make([]byte, 5)
+	needed, code := copyString(buffer, "2081")
+	assert.Equal(t, code, codeOK)
+	assert.Equal(t, needed, 5)
+	assert.Equal(t, buffer, []byte{'2', '0', '8', '1', 0})
+
+	buffer = []byte{'x', 'x', 'x', 'x'}
+	needed, code = copyString(buffer, "2081")
+	assert.Equal(t, code, codeBufferTooSmall)
+	assert.Equal(t, needed, 5)
+	assert.Equal(t, buffer, []byte{'x', 'x', 'x', 'x'})
+
+	needed, code = copyString(nil, "")
+	assert.Equal(t, []int{needed, code}, []int{1, codeBufferTooSmall})
+}
@@ -0,0 +1,108 @@
+//go:build cgo
+// +build cgo
+
+package main
+
+/*
+#include <stddef.h>
+
+#define BSDATE_OK                  0
+#define BSDATE_INVALID_DATE        1
+#define BSDATE_INVALID_MONTH_TYPE  2
+#define BSDATE_MISSING_DATA        3
+#define BSDATE_BUFFER_TOO_SMALL    4
+#define BSDATE_INVALID_ARGUMENT    5
+#define BSDATE_UNKNOWN_ERROR       6
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+// messages are allocated once and never freed, so BSErrorMessage can hand them out
+var messages = map[int]*C.char{}
+
+func init() {
+	for code, message := range errorMessages {
+		messages[code] = C.CString(message)
+	}
+}
+
+// BSToGregorian converts a BS date into a gregorian date
+//
+//export BSToGregorian
+func BSToGregorian(year, month, day C.int, gregorianYear, gregorianMonth, gregorianDay *C.int) C.int {
+	if gregorianYear == nil || gregorianMonth == nil || gregorianDay == nil {
+		return codeInvalidArgument
+	}
+	gregorianDate, code := toGregorian(int(year), int(month), int(day))
+	if code != codeOK {
+		return C.int(code)
+	}
+	*gregorianYear = C.int(gregorianDate.Year())
+	*gregorianMonth = C.int(gregorianDate.Month())
+	*gregorianDay = C.int(gregorianDate.Day())
+	return codeOK
+}
+
+// BSFromGregorian converts a gregorian date into a BS date
+//
+//export BSFromGregorian
+func BSFromGregorian(gregorianYear, gregorianMonth, gregorianDay C.int, year, month, day *C.int) C.int {
+	if year == nil || month == nil || day == nil {
+		return codeInvalidArgument
+	}
+	d, code := toBS(int(gregorianYear), int(gregorianMonth), int(gregorianDay))
+	if code != codeOK {
+		return C.int(code)
+	}
+	*year = C.int(d.GetYear())
+	*month = C.int(d.GetMonth())
+	*day = C.int(d.GetDay())
+	return codeOK
+}
+
+// BSValidate returns BSDATE_OK if the BS date exists
+//
+//export BSValidate
+func BSValidate(year, month, day C.int) C.int {
+	return C.int(validate(int(year), int(month), int(day)))
+}
+
+// BSFormat writes the BS date with the layout, e.g. "DD MMMM YYYY", into buffer which has room for size bytes.
+// needed is set to the size the buffer must have, it may be NULL
+//
+//export BSFormat
+func BSFormat(year, month, day C.int, layout *C.char, buffer *C.char, size C.size_t, needed *C.size_t) C.int {
+	if layout == nil || (buffer == nil && size > 0) {
+		return codeInvalidArgument
+	}
+	formatted, code := format(int(year), int(month), int(day), C.GoString(layout))
+	if code != codeOK {
+		return C.int(code)
+	}
+	var usable = usableSize(uint64(size), formatted)
+	if usable > maxBufferSize {
+		return codeInvalidArgument
+	}
+	var target []byte
+	if usable > 0 {
+		target = (*[maxBufferSize]byte)(unsafe.Pointer(buffer))[:usable:usable]
+	}
+	neededSize, code := copyString(target, formatted)
+	if needed != nil {
+		*needed = C.size_t(neededSize)
+	}
+	return C.int(code)
+}
+
+// BSErrorMessage describes a code, the message belongs to the library
+//
+//export BSErrorMessage
+func BSErrorMessage(code C.int) *C.char {
+	if message, ok := messages[int(code)]; ok {
+		return message
+	}
+	return messages[codeUnknown]
+}
@@ -0,0 +1,41 @@
+// Command bsdate-server runs the conversion service of the server package.
+//
+//	bsdate-server [-addr :8080] [-holidays holidays.json]
+//
+// Without -holidays the national holidays of this module are served
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+	"github.com/JankariTech/GoBikramSambat/server"
+)
+
+func main() {
+	var addr = flag.String("addr", ":8080", "address to listen on")
+	var holidaysFile = flag.String("holidays", "", "JSON file with the holidays to serve instead of the national holidays")
+	flag.Parse()
+
+	var registry *bsdate.HolidayRegistry
+	if *holidaysFile != "" {
+		holidays, err := bsdate.LoadHolidaysFile(*holidaysFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if registry, err = bsdate.NewHolidayRegistry(holidays...); err != nil {
+			log.Fatal(err)
+		}
+	}
+	var httpServer = &http.Server{
+		Addr:         *addr,
+		Handler:      server.New(registry),
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+	log.Println("listening on " + *addr)
+	log.Fatal(httpServer.ListenAndServe())
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+)
+
+type calendarMonth struct {
+	Year  int      `json:"year"`
+	Month int      `json:"month"`
+	Name  string   `json:"name"`
+	Weeks [][7]int `json:"weeks"`
+}
+
+// calendar is a month or a year, the text output is the grid, JSON and CSV list the weeks with 0 for empty days
+type calendar struct {
+	grid   string
+	months []calendarMonth
+}
+
+func (c calendar) text() string {
+	return c.grid
+}
+
+func (c calendar) header() []string {
+	return []string{"year", "month", "sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+}
+
+func (c calendar) rows() [][]string {
+	var rows [][]string
+	for _, month := range c.months {
+		for _, week := range month.Weeks {
+			var row = []string{strconv.Itoa(month.Year), strconv.Itoa(month.Month)}
+			for _, day := range week {
+				row = append(row, strconv.Itoa(day))
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// MarshalJSON writes the months as an array, also if there is only a single month
+func (c calendar) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.months)
+}
+
+// runCal prints the current month, a whole year or a single month of a year
+func runCal(args []string) (result, error) {
+	var flags = flag.NewFlagSet("cal", flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+	var options bsdate.GridOptions
+	flags.BoolVar(&options.ShowGregorian, "gregorian", false, "show the gregorian days")
+	flags.BoolVar(&options.Devanagari, "devanagari", false, "use Devanagari digits")
+	var color = flags.Bool("color", stdoutIsTerminal(), "highlight Saturdays, the default if stdout is a terminal")
+	if err := flags.Parse(args); err != nil {
+		return nil, usageError{err.Error()}
+	}
+	options.Highlight = *color
+
+	var year, month int
+	switch flags.NArg() {
+	case 0:
+		d, err := today()
+		if err != nil {
+			return nil, err
+		}
+		year, month = d.GetYear(), d.GetMonth()
+	case 1, 2:
+		var err error
+		if year, err = strconv.Atoi(flags.Arg(0)); err != nil {
+			return nil, usageError{flags.Arg(0) + " is not a year"}
+		}
+		if flags.NArg() == 2 {
+			if month, err = parseMonth(flags.Arg(1)); err != nil {
+				return nil, usageError{err.Error()}
+			}
+		}
+	default:
+		return nil, usageError{"cal takes at most a year and a month"}
+	}
+
+	var res calendar
+	var months = []int{month}
+	var err error
+	if month == 0 {
+		months = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+		res.grid, err = bsdate.YearGrid(year, options)
+	} else {
+		res.grid, err = bsdate.MonthGrid(year, month, options)
+	}
+	if err != nil {
+		return nil, errors.New("no data for " + strings.Join(flags.Args(), " "))
+	}
+	for _, m := range months {
+		weeks, err := bsdate.MonthWeeks(year, m)
+		if err != nil {
+			return nil, err
+		}
+		res.months = append(res.months, calendarMonth{Year: year, Month: m, Name: bsdate.MonthNames[m-1], Weeks: weeks})
+	}
+	return res, nil
+}
+
+// parseMonth reads a month number or name
+func parseMonth(value string) (int, error) {
+	if month, err := strconv.Atoi(value); err == nil {
+		if month < 1 || month > 12 {
+			return 0, errors.New("month has to be between 1 and 12")
+		}
+		return month, nil
+	}
+	for i, name := range bsdate.MonthNames {
+		if strings.EqualFold(name, value) {
+			return i + 1, nil
+		}
+	}
+	return 0, errors.New(value + " is not a month")
+}
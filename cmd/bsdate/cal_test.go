@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+	"github.com/magiconair/properties/assert"
+)
+
+func TestCal(t *testing.T) {
+	today = func() (bsdate.Date, error) {
+		return bsdate.New(15, 1, 2081)
+	}
+	defer func() { today = bsdate.Today }()
+	var isTerminal = stdoutIsTerminal
+	defer func() { stdoutIsTerminal = isTerminal }()
+
+	var tests = []struct {
+		args     []string
+		terminal bool
+		expected string
+	}{
+		{[]string{"cal"}, false, "    Baisakh 2081\nSu Mo Tu We Th Fr Sa\n                   1\n"},
+		{[]string{"cal", "2081", "baisakh"}, false, "    Baisakh 2081\nSu Mo Tu We Th Fr Sa\n                   1\n"},
+		{[]string{"cal", "-devanagari", "2081", "1"}, false, "    Baisakh २०८१\nSu Mo Tu We Th Fr Sa\n                   १\n"},
+		{[]string{"cal", "-gregorian", "2081", "1"}, false, "    Baisakh 2081\n    Apr/May 2024\nSu Mo Tu We Th Fr Sa\n                   1\n                  13\n"},
+		{[]string{"cal", "2081"}, false, "                              2081\n\n    Baisakh 2081          Jestha 2081           Ashadh 2081\n"},
+		{[]string{"cal", "2081", "1"}, true, "    Baisakh 2081\nSu Mo Tu We Th Fr \x1b[31mSa\x1b[0m\n                  \x1b[31m 1\x1b[0m\n"},
+		{[]string{"cal", "-color=false", "2081", "1"}, true, "    Baisakh 2081\nSu Mo Tu We Th Fr Sa\n                   1\n"},
+		{[]string{"cal", "-color", "2081", "1"}, false, "    Baisakh 2081\nSu Mo Tu We Th Fr \x1b[31mSa\x1b[0m\n"},
+		{[]string{"-format", "csv", "cal", "2081", "1"}, false, "year,month,sun,mon,tue,wed,thu,fri,sat\n2081,1,0,0,0,0,0,0,1\n2081,1,2,3,4,5,6,7,8\n"},
+		{[]string{"-format", "json", "cal", "2081", "1"}, false, "[\n  {\n    \"year\": 2081,\n    \"month\": 1,\n    \"name\": \"Baisakh\",\n    \"weeks\": [\n      [\n        0,\n"},
+	}
+	for _, test := range tests {
+		t.Run(strings.Join(test.args, " "), func(t *testing.T) {
+			stdoutIsTerminal = func() bool { return test.terminal }
+			code, stdout, stderr := runForTest(test.args...)
+			assert.Equal(t, code, exitOK)
+			assert.Equal(t, strings.HasPrefix(stdout, test.expected), true, stdout)
+			assert.Equal(t, stderr, "")
+		})
+	}
+}
+
+func TestCalErrors(t *testing.T) {
+	var tests = []struct {
+		args   []string
+		code   int
+		stderr string
+	}{
+		{[]string{"cal", "-week"}, exitUsage, "flag provided but not defined: -week"},
+		{[]string{"cal", "2081", "Magha"}, exitUsage, "Magha is not a month"},
+		{[]string{"cal", "2081", "13"}, exitUsage, "month has to be between 1 and 12"},
+		{[]string{"cal", "2081", "1", "1"}, exitUsage, "cal takes at most"},
+		{[]string{"cal", "2200"}, exitInvalid, "no data for 2200"},
+	}
+	for _, test := range tests {
+		t.Run(strings.Join(test.args, " "), func(t *testing.T) {
+			code, stdout, stderr := runForTest(test.args...)
+			assert.Equal(t, code, test.code)
+			assert.Equal(t, stdout, "")
+			assert.Equal(t, strings.Contains(stderr, test.stderr), true, stderr)
+		})
+	}
+}
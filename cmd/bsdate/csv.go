@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+	"github.com/JankariTech/GoBikramSambat/csvconv"
+)
+
+// columnNames collects the values of a flag that can be given multiple times
+type columnNames []string
+
+func (c *columnNames) String() string {
+	return strings.Join(*c, ",")
+}
+
+func (c *columnNames) Set(value string) error {
+	*c = append(*c, value)
+	return nil
+}
+
+var policies = map[string]csvconv.Policy{"fail": csvconv.Fail, "blank": csvconv.Blank, "flag": csvconv.Flag}
+
+// runCSV converts columns of a CSV file or stdin and writes the result to stdout, the report goes to stderr
+func runCSV(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	var flags = flag.NewFlagSet("csv", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var toAD, toBS columnNames
+	flags.Var(&toAD, "toad", "convert this BS column to gregorian, can be used multiple times")
+	flags.Var(&toBS, "tobs", "convert this gregorian column to BS, can be used multiple times")
+	var tsv = flags.Bool("tsv", false, "read and write tab separated values")
+	var policy = flags.String("policy", "fail", "what to do with invalid dates: fail, blank or flag, the exit code is 1 if any date is invalid")
+	var bsLayout = flags.String("bs-layout", bsdate.ISOLayout, "layout of BS dates")
+	var adLayout = flags.String("ad-layout", gregorianLayout, "layout of gregorian dates, as in the go time package")
+	var replace = flags.Bool("replace", false, "replace the columns instead of adding columns with the suffix _ad or _bs")
+	if err := flags.Parse(args); err != nil {
+		return usageError{err.Error()}
+	}
+	if len(toAD)+len(toBS) == 0 {
+		return usageError{"csv needs at least one column to convert"}
+	}
+	if flags.NArg() > 1 {
+		return usageError{"csv reads at most one file"}
+	}
+	var converter = csvconv.Converter{}
+	var ok bool
+	if converter.Policy, ok = policies[*policy]; !ok {
+		return usageError{"unknown policy " + *policy}
+	}
+	if *tsv {
+		converter.Comma = '\t'
+	}
+	for _, name := range toAD {
+		var column = csvconv.Column{Name: name, Direction: csvconv.ToGregorian, InputLayout: *bsLayout, OutputLayout: *adLayout}
+		if !*replace {
+			column.Output = name + "_ad"
+		}
+		converter.Columns = append(converter.Columns, column)
+	}
+	for _, name := range toBS {
+		var column = csvconv.Column{Name: name, Direction: csvconv.ToBS, InputLayout: *adLayout, OutputLayout: *bsLayout}
+		if !*replace {
+			column.Output = name + "_bs"
+		}
+		converter.Columns = append(converter.Columns, column)
+	}
+
+	var input = stdin
+	if flags.NArg() == 1 {
+		file, err := os.Open(flags.Arg(0))
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		input = file
+	}
+	report, err := converter.Convert(input, stdout)
+	if err != nil {
+		return err
+	}
+	for _, column := range report.Columns {
+		fmt.Fprintf(stderr, "%s: %d converted, %d failed\n", column.Column, column.Converted, column.Failed)
+		for _, cellErr := range column.Errors {
+			fmt.Fprintln(stderr, "  "+cellErr.Error())
+		}
+		if column.Failed > len(column.Errors) {
+			fmt.Fprintf(stderr, "  ... and %d more\n", column.Failed-len(column.Errors))
+		}
+	}
+	//with the blank and flag policies the file is written completely, but the exit code still shows the failed cells
+	if failed := report.Failed(); failed > 0 {
+		if failed == 1 {
+			return errors.New("1 cell could not be converted")
+		}
+		return errors.New(strconv.Itoa(failed) + " cells could not be converted")
+	}
+	return nil
+}
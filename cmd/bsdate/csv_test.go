@@ -0,0 +1,62 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestCSV(t *testing.T) {
+	var tests = []struct {
+		args   []string
+		input  string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{[]string{"csv", "-toad", "born"}, "name,born\nSita,2081-01-01\n", exitOK,
+			"name,born,born_ad\nSita,2081-01-01,2024-04-13\n", "born: 1 converted, 0 failed\n"},
+		{[]string{"csv", "-tsv", "-replace", "-tobs", "joined", "-bs-layout", "D MMMM YYYY"}, "name\tjoined\nRam\t2024-07-15\n", exitOK,
+			"name\tjoined\nRam\t32 Ashadh 2081\n", "joined: 1 converted, 0 failed\n"},
+		{[]string{"csv", "-policy", "blank", "-toad", "born"}, "born\n2081-02-32\n", exitInvalid,
+			"born,born_ad\n2081-02-32,\n", "born: 0 converted, 1 failed\n  born, row 2: \"2081-02-32\": not a valid date\n" +
+				"bsdate: 1 cell could not be converted\n"},
+		{[]string{"csv", "-policy", "flag", "-replace", "-toad", "born"}, "born\n2081-02-32\n2081-13-01\n2081-01-01\n", exitInvalid,
+			"born,born_error\n,not a valid date\n,not a valid date\n2024-04-13,\n", "born: 1 converted, 2 failed\n"},
+		{[]string{"csv", "-toad", "born"}, "born\n2081-01-01\n2081-02-32\n", exitInvalid,
+			"born,born_ad\n2081-01-01,2024-04-13\n", "bsdate: born, row 3: \"2081-02-32\": not a valid date\n"},
+		{[]string{"csv", "-toad", "date"}, "born\n", exitInvalid, "", "bsdate: column date is not in the header\n"},
+		{[]string{"csv"}, "", exitUsage, "", "bsdate: csv needs at least one column to convert\n"},
+		{[]string{"csv", "-policy", "ignore", "-toad", "born"}, "", exitUsage, "", "bsdate: unknown policy ignore\n"},
+	}
+	for _, test := range tests {
+		t.Run(strings.Join(test.args, " "), func(t *testing.T) {
+			stdin = strings.NewReader(test.input)
+			defer func() { stdin = os.Stdin }()
+			code, stdout, stderr := runForTest(test.args...)
+			assert.Equal(t, code, test.code)
+			assert.Equal(t, stdout, test.stdout)
+			assert.Equal(t, strings.HasPrefix(stderr, test.stderr), true, stderr)
+		})
+	}
+}
+
+func TestCSVFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "bsdate")
+	assert.Equal(t, err, nil)
+	defer os.RemoveAll(dir)
+	var path = filepath.Join(dir, "dates.csv")
+	assert.Equal(t, ioutil.WriteFile(path, []byte("date\n2024-04-13\n"), 0644), nil)
+
+	code, stdout, _ := runForTest("csv", "-tobs", "date", path)
+	assert.Equal(t, code, exitOK)
+	assert.Equal(t, stdout, "date,date_bs\n2024-04-13,2081-01-01\n")
+
+	code, _, stderr := runForTest("csv", "-tobs", "date", filepath.Join(dir, "missing.csv"))
+	assert.Equal(t, code, exitInvalid)
+	assert.Equal(t, strings.Contains(stderr, "missing.csv"), true)
+}
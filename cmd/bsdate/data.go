@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/JankariTech/GoBikramSambat/caldata"
+)
+
+// runData writes the calendar data of this module or compares files with it
+//
+//	data export        writes the data to stdout
+//	data diff [FILE]   lists the years in which the file (or stdin) disagrees with the data
+func runData(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	var flags = flag.NewFlagSet("data", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var schema = flags.String("schema", "json", "format of the data: "+strings.Join(caldata.FormatNames(), ", "))
+	if err := flags.Parse(args); err != nil {
+		return usageError{err.Error()}
+	}
+	format, err := caldata.ParseFormat(*schema)
+	if err != nil {
+		return usageError{err.Error()}
+	}
+	switch flags.Arg(0) {
+	case "export":
+		if flags.NArg() > 1 {
+			return usageError{"data export takes no files"}
+		}
+		return caldata.Write(stdout, caldata.Current(), format)
+	case "diff":
+		if flags.NArg() > 2 {
+			return usageError{"data diff reads at most one file"}
+		}
+		var input = stdin
+		if flags.NArg() == 2 {
+			file, err := os.Open(flags.Arg(1))
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			input = file
+		}
+		other, err := caldata.Read(input, format)
+		if err != nil {
+			return err
+		}
+		var differences = caldata.Diff(caldata.Current(), other)
+		for _, difference := range differences {
+			fmt.Fprintln(stdout, difference.String())
+		}
+		if len(differences) > 0 {
+			return errors.New(strconv.Itoa(len(differences)) + " years differ")
+		}
+		return nil
+	}
+	return usageError{"data needs the action export or diff"}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestData(t *testing.T) {
+	code, stdout, _ := runForTest("data", "-schema", "csv", "export")
+	assert.Equal(t, code, exitOK)
+	assert.Equal(t, strings.Contains(stdout, "\n2081,17,31,31,32,32,31,30,30,30,29,30,30,30\n"), true)
+
+	var tests = []struct {
+		args   []string
+		input  string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{[]string{"data", "-schema", "nepali-date-converter", "diff"},
+			"{2081: [31, 31, 32, 32, 31, 30, 30, 30, 29, 30, 30, 30]}", exitInvalid,
+			"1970: only in the base dataset\n", "bsdate: 130 years differ\n"},
+		{[]string{"data", "-schema", "csv", "diff"}, stdout, exitOK, "", ""},
+		{[]string{"data", "-schema", "xml", "export"}, "", exitUsage, "", "bsdate: unknown format xml"},
+		{[]string{"data"}, "", exitUsage, "", "bsdate: data needs the action export or diff\n"},
+	}
+	for _, test := range tests {
+		t.Run(strings.Join(test.args, " "), func(t *testing.T) {
+			stdin = strings.NewReader(test.input)
+			defer func() { stdin = os.Stdin }()
+			code, stdout, stderr := runForTest(test.args...)
+			assert.Equal(t, code, test.code)
+			assert.Equal(t, strings.HasPrefix(stdout, test.stdout), true, stdout)
+			assert.Equal(t, strings.HasPrefix(stderr, test.stderr), true, stderr)
+		})
+	}
+}
@@ -0,0 +1,400 @@
+// Command bsdate converts dates between Bikram Sambat (BS) and the gregorian calendar (AD).
+//
+//	bsdate [-format text|json|csv] command [arguments]
+//
+//	today                   the current date in Nepal
+//	tobs YYYY-MM-DD...      convert gregorian dates to BS
+//	toad YYYY-MM-DD...      convert BS dates to gregorian
+//	validate YYYY-MM-DD...  check if BS dates exist
+//	info YEAR               the length of the months of a BS year
+//	cal [-gregorian] [-devanagari] [-color] [YEAR [MONTH]]
+//	                        a month or year as a grid like the unix cal command, Saturdays are
+//	                        highlighted if stdout is a terminal and -color=false is not given
+//	csv [-toad COLUMN]... [-tobs COLUMN]... [FILE]
+//	                        add converted columns to a CSV file, see "bsdate csv -h" for all options
+//	data [-schema FORMAT] export|diff [FILE]
+//	                        export the calendar data or list the years in which a file disagrees with it
+//
+// With -format json dates and months are always written as an array, also if there is only one of them (info writes a single object).
+// Errors are written to stderr. The exit code is 0 on success, 1 if a date is invalid or cannot be converted
+// and 2 if the command is used wrongly
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+)
+
+const (
+	exitOK      = 0
+	exitInvalid = 1
+	exitUsage   = 2
+)
+
+const gregorianLayout = "2006-01-02"
+
+// today, stdin and stdoutIsTerminal are replaced in the tests
+var today = bsdate.Today
+var stdin io.Reader = os.Stdin
+var stdoutIsTerminal = func() bool {
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// result is the output of a command, it can be written as text, JSON or CSV
+type result interface {
+	text() string
+	header() []string
+	rows() [][]string
+}
+
+// usageError is returned for wrong arguments, it leads to exitUsage
+type usageError struct {
+	message string
+}
+
+func (e usageError) Error() string {
+	return e.message
+}
+
+type command struct {
+	usage string
+	run   func(args []string) (result, error)
+	// stream is used instead of run by commands that write their output while they read their input
+	stream func(args []string, stdin io.Reader, stdout, stderr io.Writer) error
+}
+
+// commands is filled in init, so the commands can refer to it, e.g. to print the usage
+var commands map[string]command
+
+var commandOrder = []string{"today", "tobs", "toad", "validate", "info", "cal", "csv", "data"}
+
+func init() {
+	commands = map[string]command{
+		"today":    {usage: "today", run: runToday},
+		"tobs":     {usage: "tobs YYYY-MM-DD...", run: runToBS},
+		"toad":     {usage: "toad YYYY-MM-DD...", run: runToAD},
+		"validate": {usage: "validate YYYY-MM-DD...", run: runValidate},
+		"info":     {usage: "info YEAR", run: runInfo},
+		"cal":      {usage: "cal [-gregorian] [-devanagari] [-color] [YEAR [MONTH]]", run: runCal},
+		"csv": {
+			usage:  "csv [-tsv] [-policy fail|blank|flag] [-bs-layout LAYOUT] [-ad-layout LAYOUT] [-replace] [-toad COLUMN]... [-tobs COLUMN]... [FILE]",
+			stream: runCSV,
+		},
+		"data": {usage: "data [-schema json|csv|nepali-datetime|nepali-date-converter] export|diff [FILE]", stream: runData},
+	}
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command line and returns the exit code
+func run(args []string, stdout, stderr io.Writer) int {
+	var flags = flag.NewFlagSet("bsdate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var format = flags.String("format", "text", "output format: text, json or csv")
+	flags.Usage = func() {
+		printUsage(stderr)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if *format != "text" && *format != "json" && *format != "csv" {
+		fmt.Fprintln(stderr, "bsdate: unknown format "+*format)
+		return exitUsage
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return exitUsage
+	}
+	cmd, ok := commands[flags.Arg(0)]
+	if !ok {
+		fmt.Fprintln(stderr, "bsdate: unknown command "+flags.Arg(0))
+		printUsage(stderr)
+		return exitUsage
+	}
+
+	var res result
+	var err error
+	if cmd.stream != nil {
+		err = cmd.stream(flags.Args()[1:], stdin, stdout, stderr)
+	} else {
+		res, err = cmd.run(flags.Args()[1:])
+	}
+	if _, isUsageError := err.(usageError); isUsageError {
+		fmt.Fprintln(stderr, "bsdate: "+err.Error())
+		fmt.Fprintln(stderr, "usage: bsdate "+cmd.usage)
+		return exitUsage
+	}
+	var exitCode = exitOK
+	if err != nil {
+		fmt.Fprintln(stderr, "bsdate: "+err.Error())
+		exitCode = exitInvalid
+	}
+	//if nothing could be converted there is nothing to write, not even a CSV header
+	if res != nil && (err == nil || len(res.rows()) > 0) {
+		if writeErr := write(stdout, res, *format); writeErr != nil {
+			fmt.Fprintln(stderr, "bsdate: "+writeErr.Error())
+			return exitInvalid
+		}
+	}
+	return exitCode
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage: bsdate [-format text|json|csv] command [arguments]")
+	fmt.Fprintln(w, "commands:")
+	for _, name := range commandOrder {
+		fmt.Fprintln(w, "  "+commands[name].usage)
+	}
+}
+
+func write(w io.Writer, res result, format string) error {
+	switch format {
+	case "json":
+		var encoder = json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(res)
+	case "csv":
+		var writer = csv.NewWriter(w)
+		if err := writer.Write(res.header()); err != nil {
+			return err
+		}
+		if err := writer.WriteAll(res.rows()); err != nil {
+			return err
+		}
+		return writer.Error()
+	default:
+		_, err := io.WriteString(w, res.text())
+		return err
+	}
+}
+
+// conversion is a date in both calendars
+type conversion struct {
+	BS        string `json:"bs"`
+	AD        string `json:"ad"`
+	MonthName string `json:"month_name"`
+	Weekday   string `json:"weekday"`
+}
+
+func newConversion(d bsdate.Date, gregorianDate time.Time) conversion {
+	return conversion{
+		BS:        bsdate.Format(d, bsdate.ISOLayout),
+		AD:        gregorianDate.Format(gregorianLayout),
+		MonthName: d.GetMonthName(),
+		Weekday:   gregorianDate.Weekday().String(),
+	}
+}
+
+// conversions are always written as a JSON array, even if there is only one of them
+type conversions []conversion
+
+func (c conversions) text() string {
+	var text strings.Builder
+	for _, conv := range c {
+		text.WriteString(conv.BS + " " + conv.AD + " " + conv.MonthName + " " + conv.Weekday + "\n")
+	}
+	return text.String()
+}
+
+func (c conversions) header() []string {
+	return []string{"bs", "ad", "month_name", "weekday"}
+}
+
+func (c conversions) rows() [][]string {
+	var rows [][]string
+	for _, conv := range c {
+		rows = append(rows, []string{conv.BS, conv.AD, conv.MonthName, conv.Weekday})
+	}
+	return rows
+}
+
+func runToday(args []string) (result, error) {
+	if len(args) != 0 {
+		return nil, usageError{"today takes no arguments"}
+	}
+	d, err := today()
+	if err != nil {
+		return nil, err
+	}
+	gregorianDate, err := d.GetGregorianDate()
+	if err != nil {
+		return nil, err
+	}
+	return conversions{newConversion(d, gregorianDate)}, nil
+}
+
+// runToBS converts all dates it can, the error mentions the first date that failed
+func runToBS(args []string) (result, error) {
+	if len(args) == 0 {
+		return nil, usageError{"tobs needs at least one date"}
+	}
+	var res conversions
+	var firstErr error
+	for _, arg := range args {
+		gregorianDate, err := time.Parse(gregorianLayout, arg)
+		if err != nil {
+			firstErr = keepFirst(firstErr, errors.New(arg+" is not a date in the form YYYY-MM-DD"))
+			continue
+		}
+		d, err := bsdate.NewFromGregorian(gregorianDate.Day(), int(gregorianDate.Month()), gregorianDate.Year())
+		if err != nil {
+			firstErr = keepFirst(firstErr, errors.New(arg+": "+err.Error()))
+			continue
+		}
+		res = append(res, newConversion(d, gregorianDate))
+	}
+	return res, firstErr
+}
+
+func runToAD(args []string) (result, error) {
+	if len(args) == 0 {
+		return nil, usageError{"toad needs at least one date"}
+	}
+	var res conversions
+	var firstErr error
+	for _, arg := range args {
+		d, err := bsdate.Parse(bsdate.ISOLayout, arg)
+		if err != nil {
+			firstErr = keepFirst(firstErr, err)
+			continue
+		}
+		gregorianDate, err := d.GetGregorianDate()
+		if err != nil {
+			firstErr = keepFirst(firstErr, errors.New(arg+": "+err.Error()))
+			continue
+		}
+		res = append(res, newConversion(d, gregorianDate))
+	}
+	return res, firstErr
+}
+
+func keepFirst(first, err error) error {
+	if first != nil {
+		return first
+	}
+	return err
+}
+
+type validation struct {
+	Date  string `json:"date"`
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
+type validations []validation
+
+func (v validations) text() string {
+	var text strings.Builder
+	for _, validation := range v {
+		if validation.Valid {
+			text.WriteString(validation.Date + " valid\n")
+		} else {
+			text.WriteString(validation.Date + " invalid: " + validation.Error + "\n")
+		}
+	}
+	return text.String()
+}
+
+func (v validations) header() []string {
+	return []string{"date", "valid", "error"}
+}
+
+func (v validations) rows() [][]string {
+	var rows [][]string
+	for _, validation := range v {
+		rows = append(rows, []string{validation.Date, strconv.FormatBool(validation.Valid), validation.Error})
+	}
+	return rows
+}
+
+// runValidate reports every date, the exit code tells if all of them are valid
+func runValidate(args []string) (result, error) {
+	if len(args) == 0 {
+		return nil, usageError{"validate needs at least one date"}
+	}
+	var res validations
+	var invalid = 0
+	for _, arg := range args {
+		var v = validation{Date: arg, Valid: true}
+		if _, err := bsdate.Parse(bsdate.ISOLayout, arg); err != nil {
+			v.Valid = false
+			v.Error = err.Error()
+			invalid++
+		}
+		res = append(res, v)
+	}
+	if invalid > 0 {
+		return res, errors.New(strconv.Itoa(invalid) + " of " + strconv.Itoa(len(args)) + " dates are invalid")
+	}
+	return res, nil
+}
+
+type monthInfo struct {
+	Month int    `json:"month"`
+	Name  string `json:"name"`
+	Days  int    `json:"days"`
+}
+
+type yearInfo struct {
+	Year   int         `json:"year"`
+	Days   int         `json:"days"`
+	Months []monthInfo `json:"months"`
+}
+
+func (y yearInfo) text() string {
+	var text strings.Builder
+	for _, month := range y.Months {
+		text.WriteString(fmt.Sprintf("%2d %-8s %d\n", month.Month, month.Name, month.Days))
+	}
+	text.WriteString(fmt.Sprintf("   %-8s %d\n", "total", y.Days))
+	return text.String()
+}
+
+func (y yearInfo) header() []string {
+	return []string{"month", "name", "days"}
+}
+
+func (y yearInfo) rows() [][]string {
+	var rows [][]string
+	for _, month := range y.Months {
+		rows = append(rows, []string{strconv.Itoa(month.Month), month.Name, strconv.Itoa(month.Days)})
+	}
+	return rows
+}
+
+func runInfo(args []string) (result, error) {
+	if len(args) != 1 {
+		return nil, usageError{"info needs exactly one year"}
+	}
+	year, err := strconv.Atoi(args[0])
+	if err != nil {
+		return nil, usageError{args[0] + " is not a year"}
+	}
+	var info = yearInfo{Year: year}
+	for month := 1; month <= 12; month++ {
+		days, err := bsdate.DaysInMonth(year, month)
+		if err != nil {
+			return nil, errors.New("no data for the year " + args[0])
+		}
+		info.Months = append(info.Months, monthInfo{Month: month, Name: bsdate.MonthNames[month-1], Days: days})
+		info.Days += days
+	}
+	return info, nil
+}
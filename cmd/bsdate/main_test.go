@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+	"github.com/magiconair/properties/assert"
+)
+
+func runForTest(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	var code = run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCommands(t *testing.T) {
+	today = func() (bsdate.Date, error) {
+		return bsdate.New(1, 1, 2081)
+	}
+	defer func() { today = bsdate.Today }()
+
+	var tests = []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"today"}, "2081-01-01 2024-04-13 Baisakh Saturday\n"},
+		{[]string{"tobs", "2024-04-13"}, "2081-01-01 2024-04-13 Baisakh Saturday\n"},
+		{[]string{"toad", "2081-01-01", "2081-03-32"}, "2081-01-01 2024-04-13 Baisakh Saturday\n2081-03-32 2024-07-15 Ashadh Monday\n"},
+		{[]string{"-format", "json", "tobs", "2024-04-13"},
+			"[\n  {\n    \"bs\": \"2081-01-01\",\n    \"ad\": \"2024-04-13\",\n    \"month_name\": \"Baisakh\",\n    \"weekday\": \"Saturday\"\n  }\n]\n"},
+		{[]string{"-format", "csv", "toad", "2081-01-01"}, "bs,ad,month_name,weekday\n2081-01-01,2024-04-13,Baisakh,Saturday\n"},
+		{[]string{"validate", "2081-03-32"}, "2081-03-32 valid\n"},
+		{[]string{"-format", "csv", "info", "2081"},
+			"month,name,days\n1,Baisakh,31\n2,Jestha,31\n3,Ashadh,32\n4,Shrawan,32\n5,Bhadra,31\n6,Ashwin,30\n" +
+				"7,Kartik,30\n8,Mangsir,30\n9,Paush,29\n10,Mangh,30\n11,Falgun,30\n12,Chaitra,30\n"},
+	}
+	for _, test := range tests {
+		t.Run(strings.Join(test.args, " "), func(t *testing.T) {
+			code, stdout, stderr := runForTest(test.args...)
+			assert.Equal(t, code, exitOK)
+			assert.Equal(t, stdout, test.expected)
+			assert.Equal(t, stderr, "")
+		})
+	}
+}
+
+func TestInfoText(t *testing.T) {
+	code, stdout, _ := runForTest("info", "2081")
+	assert.Equal(t, code, exitOK)
+	assert.Equal(t, strings.HasPrefix(stdout, " 1 Baisakh  31\n"), true)
+	assert.Equal(t, strings.HasSuffix(stdout, "12 Chaitra  30\n   total    366\n"), true)
+}
+
+func TestErrors(t *testing.T) {
+	var tests = []struct {
+		args   []string
+		code   int
+		stdout string
+		stderr string
+	}{
+		{[]string{}, exitUsage, "", "usage: bsdate"},
+		{[]string{"-format", "xml", "today"}, exitUsage, "", "unknown format xml"},
+		{[]string{"convert"}, exitUsage, "", "unknown command convert"},
+		{[]string{"tobs"}, exitUsage, "", "usage: bsdate tobs"},
+		{[]string{"info", "twenty"}, exitUsage, "", "twenty is not a year"},
+		{[]string{"info", "2200"}, exitInvalid, "", "no data for the year 2200"},
+		{[]string{"tobs", "13-04-2024"}, exitInvalid, "", "13-04-2024 is not a date"},
+		{[]string{"tobs", "1800-01-01"}, exitInvalid, "", "1800-01-01: cannot convert date"},
+		{[]string{"toad", "2081-02-32", "2081-01-01"}, exitInvalid, "2081-01-01 2024-04-13 Baisakh Saturday\n", "not a valid date"},
+		{[]string{"validate", "2081-01-01", "2081-02-32"}, exitInvalid, "2081-01-01 valid\n2081-02-32 invalid: not a valid date\n", "1 of 2 dates are invalid"},
+	}
+	for _, test := range tests {
+		t.Run(strings.Join(test.args, " "), func(t *testing.T) {
+			code, stdout, stderr := runForTest(test.args...)
+			assert.Equal(t, code, test.code)
+			assert.Equal(t, stdout, test.stdout)
+			assert.Equal(t, strings.Contains(stderr, test.stderr), true, stderr)
+		})
+	}
+}
@@ -0,0 +1,255 @@
+// Package cron reads cron expressions whose day-of-month and month fields count in BS.
+//
+// An expression has the five usual fields, evaluated in Nepal time:
+//
+//	minute hour day-of-month month day-of-week
+//
+// The day of the month goes from 1 to 32, "L" stands for the last day of the BS month.
+// Months are 1 (Baisakh) to 12 (Chaitra) or their English names and spellings, e.g. "Shrawan", "Saun" or "Magh".
+// Weekdays are 0 (Sunday) to 6 or SUN to SAT. Fields take "*", lists, ranges and steps like "1-15/2".
+// As in standard cron a day matches if either the day of the month or the weekday matches, when both are restricted.
+//
+//	0 10 1 * *        at 10:00 on the 1st of every BS month
+//	0 0 1 Shrawan *   at midnight at the start of the fiscal year
+//	30 17 L * *       at 17:30 on the last day of every BS month
+package cron
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+)
+
+var weekdayNames = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+
+// weekdayNumber returns 0 (Sunday) to 6 (Saturday) for the abbreviated english weekday names
+func weekdayNumber(name string) (int, bool) {
+	for i, weekday := range weekdayNames {
+		if strings.ToUpper(name) == weekday {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// Schedule is a parsed cron expression
+type Schedule struct {
+	minutes  [60]bool
+	hours    [24]bool
+	days     [33]bool
+	lastDay  bool
+	months   [13]bool
+	weekdays [7]bool
+	//if one of the day fields is "*" both have to match, else one of them
+	anyDay     bool
+	anyWeekday bool
+}
+
+// Parse reads a cron expression with five fields
+func Parse(expression string) (*Schedule, error) {
+	var fields = strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, errors.New("cron expression needs 5 fields, got " + strconv.Itoa(len(fields)))
+	}
+	var s = &Schedule{
+		anyDay:     fields[2] == "*",
+		anyWeekday: fields[4] == "*",
+	}
+	if err := parseField(fields[0], 0, 59, nil, s.minutes[:]); err != nil {
+		return nil, errors.New("minute: " + err.Error())
+	}
+	if err := parseField(fields[1], 0, 23, nil, s.hours[:]); err != nil {
+		return nil, errors.New("hour: " + err.Error())
+	}
+	var parts []string
+	for _, part := range strings.Split(fields[2], ",") {
+		if strings.ToUpper(part) == "L" {
+			s.lastDay = true
+			continue
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) > 0 {
+		if err := parseField(strings.Join(parts, ","), 1, 32, nil, s.days[:]); err != nil {
+			return nil, errors.New("day of month: " + err.Error())
+		}
+	}
+	if err := parseField(fields[3], 1, 12, bsdate.English.MonthNumber, s.months[:]); err != nil {
+		return nil, errors.New("month: " + err.Error())
+	}
+	var weekdays [8]bool
+	if err := parseField(fields[4], 0, 7, weekdayNumber, weekdays[:]); err != nil {
+		return nil, errors.New("day of week: " + err.Error())
+	}
+	copy(s.weekdays[:], weekdays[:7])
+	//7 is Sunday as well
+	s.weekdays[0] = s.weekdays[0] || weekdays[7]
+	return s, nil
+}
+
+// parseField marks all values of a comma separated field in "set", names are an alternative for min, min+1, ...
+func parseField(field string, min, max int, names func(string) (int, bool), set []bool) error {
+	for _, part := range strings.Split(field, ",") {
+		var step = 1
+		if slash := strings.Index(part, "/"); slash >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[slash+1:])
+			if err != nil || step < 1 {
+				return errors.New("invalid step in " + part)
+			}
+			part = part[:slash]
+		}
+		var from, to int
+		switch {
+		case part == "*":
+			from, to = min, max
+		case strings.Contains(part, "-"):
+			var bounds = strings.SplitN(part, "-", 2)
+			var err error
+			if from, err = parseValue(bounds[0], min, max, names); err != nil {
+				return err
+			}
+			if to, err = parseValue(bounds[1], min, max, names); err != nil {
+				return err
+			}
+			if from > to {
+				return errors.New("range " + part + " is backwards")
+			}
+		default:
+			var err error
+			if from, err = parseValue(part, min, max, names); err != nil {
+				return err
+			}
+			to = from
+			if step > 1 {
+				to = max
+			}
+		}
+		for value := from; value <= to; value += step {
+			set[value] = true
+		}
+	}
+	return nil
+}
+
+// parseValue reads a number or, if names is not nil, a name
+func parseValue(value string, min, max int, names func(string) (int, bool)) (int, error) {
+	if names != nil {
+		if number, ok := names(value); ok {
+			return number, nil
+		}
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.New("invalid value " + value)
+	}
+	if number < min || number > max {
+		return 0, errors.New(value + " is not between " + strconv.Itoa(min) + " and " + strconv.Itoa(max))
+	}
+	return number, nil
+}
+
+// Next returns the first time after "after" that matches the schedule, in Nepal time.
+// The zero time is returned if there is no such time within the calendar data
+func (s *Schedule) Next(after time.Time) time.Time {
+	var t = after.In(bsdate.NepalTime).Truncate(time.Minute).Add(time.Minute)
+	var hour, minute = t.Hour(), t.Minute()
+	for day := startOfDay(t); ; day = day.AddDate(0, 0, 1) {
+		matches, err := s.matchesDay(day)
+		if err != nil {
+			return time.Time{}
+		}
+		if matches {
+			if h, m, ok := s.firstTimeFrom(hour, minute); ok {
+				return time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, bsdate.NepalTime)
+			}
+		}
+		hour, minute = 0, 0
+	}
+}
+
+// Prev returns the last time before "before" that matches the schedule, in Nepal time.
+// The zero time is returned if there is no such time within the calendar data
+func (s *Schedule) Prev(before time.Time) time.Time {
+	var t = before.In(bsdate.NepalTime)
+	if t.Truncate(time.Minute).Equal(t) {
+		t = t.Add(-time.Minute)
+	}
+	var hour, minute = t.Hour(), t.Minute()
+	for day := startOfDay(t); ; day = day.AddDate(0, 0, -1) {
+		matches, err := s.matchesDay(day)
+		if err != nil {
+			return time.Time{}
+		}
+		if matches {
+			if h, m, ok := s.lastTimeUntil(hour, minute); ok {
+				return time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, bsdate.NepalTime)
+			}
+		}
+		hour, minute = 23, 59
+	}
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, bsdate.NepalTime)
+}
+
+// matchesDay checks the day, month and weekday fields, it fails if the day cannot be converted to BS
+func (s *Schedule) matchesDay(day time.Time) (bool, error) {
+	bsDay, err := bsdate.NewFromGregorian(day.Day(), int(day.Month()), day.Year())
+	if err != nil {
+		return false, err
+	}
+	if !s.months[bsDay.GetMonth()] {
+		return false, nil
+	}
+	var dayMatches = s.days[bsDay.GetDay()]
+	if s.lastDay && !dayMatches {
+		daysInMonth, err := bsdate.DaysInMonth(bsDay.GetYear(), bsDay.GetMonth())
+		dayMatches = err == nil && bsDay.GetDay() == daysInMonth
+	}
+	var weekdayMatches = s.weekdays[day.Weekday()]
+	if s.anyDay || s.anyWeekday {
+		return dayMatches && weekdayMatches, nil
+	}
+	return dayMatches || weekdayMatches, nil
+}
+
+func (s *Schedule) firstTimeFrom(hour, minute int) (int, int, bool) {
+	for h := hour; h < 24; h++ {
+		if !s.hours[h] {
+			continue
+		}
+		var m = 0
+		if h == hour {
+			m = minute
+		}
+		for ; m < 60; m++ {
+			if s.minutes[m] {
+				return h, m, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+func (s *Schedule) lastTimeUntil(hour, minute int) (int, int, bool) {
+	for h := hour; h >= 0; h-- {
+		if !s.hours[h] {
+			continue
+		}
+		var m = 59
+		if h == hour {
+			m = minute
+		}
+		for ; m >= 0; m-- {
+			if s.minutes[m] {
+				return h, m, true
+			}
+		}
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,87 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+	"github.com/magiconair/properties/assert"
+)
+
+func nepal(year int, month time.Month, day, hour, minute, second int) time.Time {
+	return time.Date(year, month, day, hour, minute, second, 0, bsdate.NepalTime)
+}
+
+func TestNext(t *testing.T) {
+	var tests = []struct {
+		expression string
+		after      time.Time
+		expected   time.Time
+	}{
+		{"0 10 1 * *", nepal(2024, 4, 13, 0, 0, 0), nepal(2024, 4, 13, 10, 0, 0)},
+		{"0 10 1 * *", nepal(2024, 4, 13, 10, 0, 0), nepal(2024, 5, 14, 10, 0, 0)},
+		{"0 0 1 Shrawan *", nepal(2024, 5, 1, 0, 0, 0), nepal(2024, 7, 16, 0, 0, 0)},
+		{"0 0 1 4 *", nepal(2024, 5, 1, 0, 0, 0), nepal(2024, 7, 16, 0, 0, 0)},
+		{"0 0 1 Magh *", nepal(2024, 5, 1, 0, 0, 0), nepal(2025, 1, 14, 0, 0, 0)},
+		{"0 0 1 saun-asoj *", nepal(2024, 8, 1, 0, 0, 0), nepal(2024, 8, 17, 0, 0, 0)},
+		{"30 17 L * *", nepal(2024, 4, 13, 0, 0, 0), nepal(2024, 5, 13, 17, 30, 0)},
+		{"0 9 1 * FRI", nepal(2024, 4, 13, 0, 0, 0), nepal(2024, 4, 13, 9, 0, 0)},
+		{"0 9 1 * FRI", nepal(2024, 4, 13, 9, 0, 0), nepal(2024, 4, 19, 9, 0, 0)},
+		{"0 9 1-5 * 5", nepal(2024, 4, 14, 9, 0, 0), nepal(2024, 4, 15, 9, 0, 0)},
+		{"*/15 * * * *", nepal(2024, 4, 13, 10, 7, 30), nepal(2024, 4, 13, 10, 15, 0)},
+		{"0 10 1 * *", time.Date(2024, 4, 13, 4, 0, 0, 0, time.UTC), time.Date(2024, 4, 13, 4, 15, 0, 0, time.UTC)},
+		{"0 0 1 1 *", nepal(2044, 1, 1, 0, 0, 0), time.Time{}},
+	}
+	for _, test := range tests {
+		t.Run(test.expression+" "+test.after.String(), func(t *testing.T) {
+			schedule, err := Parse(test.expression)
+			assert.Equal(t, err, nil)
+			var next = schedule.Next(test.after)
+			assert.Equal(t, next.Equal(test.expected), true, next.String())
+		})
+	}
+}
+
+func TestPrev(t *testing.T) {
+	var tests = []struct {
+		expression string
+		before     time.Time
+		expected   time.Time
+	}{
+		{"0 10 1 * *", nepal(2024, 5, 14, 10, 0, 0), nepal(2024, 4, 13, 10, 0, 0)},
+		{"0 10 1 * *", nepal(2024, 5, 14, 10, 0, 30), nepal(2024, 5, 14, 10, 0, 0)},
+		{"30 17 L * *", nepal(2024, 5, 14, 0, 0, 0), nepal(2024, 5, 13, 17, 30, 0)},
+		{"0 0 1 1 *", nepal(1913, 1, 1, 0, 0, 0), time.Time{}},
+	}
+	for _, test := range tests {
+		t.Run(test.expression+" "+test.before.String(), func(t *testing.T) {
+			schedule, err := Parse(test.expression)
+			assert.Equal(t, err, nil)
+			var prev = schedule.Prev(test.before)
+			assert.Equal(t, prev.Equal(test.expected), true, prev.String())
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	var tests = []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 33 * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * Magha *",
+		"* * * * 8",
+		"* * * * FUN",
+		"* * 5-1 * *",
+		"*/0 * * * *",
+	}
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			_, err := Parse(test)
+			assert.Equal(t, err != nil, true)
+		})
+	}
+}
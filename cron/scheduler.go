@@ -0,0 +1,137 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// EntryID identifies a function added to a Scheduler
+type EntryID int
+
+type entry struct {
+	id       EntryID
+	schedule *Schedule
+	f        func()
+}
+
+// Scheduler runs functions in the background whenever their cron expression matches.
+// Every run happens in its own goroutine, so a slow function does not hold up the others
+type Scheduler struct {
+	mu      sync.Mutex
+	entries []entry
+	lastID  EntryID
+	running bool
+	wake    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	//now is replaced in the tests
+	now func() time.Time
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{now: time.Now, wake: make(chan struct{}, 1)}
+}
+
+// AddFunc schedules f to run at every time the cron expression matches, it can be called while the scheduler runs
+func (s *Scheduler) AddFunc(expression string, f func()) (EntryID, error) {
+	schedule, err := Parse(expression)
+	if err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	s.lastID++
+	var id = s.lastID
+	s.entries = append(s.entries, entry{id: id, schedule: schedule, f: f})
+	s.mu.Unlock()
+	s.notify()
+	return id, nil
+}
+
+// Remove stops future runs of the function, runs that already started are not interrupted
+func (s *Scheduler) Remove(id EntryID) {
+	s.mu.Lock()
+	for i, e := range s.entries {
+		if e.id == id {
+			s.entries = append(s.entries[:i], s.entries[i+1:]...)
+			break
+		}
+	}
+	s.mu.Unlock()
+	s.notify()
+}
+
+// Start runs the scheduler in a new goroutine, starting a running scheduler does nothing
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running {
+		return
+	}
+	s.running = true
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go s.run(s.stop, s.done)
+}
+
+// Stop ends the scheduling and waits for the scheduler goroutine to finish, functions that are running keep running
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = false
+	close(s.stop)
+	var done = s.done
+	s.mu.Unlock()
+	<-done
+}
+
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) run(stop, done chan struct{}) {
+	defer close(done)
+	for {
+		var now = s.now()
+		var next time.Time
+		var due []func()
+		s.mu.Lock()
+		for _, e := range s.entries {
+			var t = e.schedule.Next(now)
+			if t.IsZero() {
+				continue
+			}
+			switch {
+			case next.IsZero() || t.Before(next):
+				next = t
+				due = []func(){e.f}
+			case t.Equal(next):
+				due = append(due, e.f)
+			}
+		}
+		s.mu.Unlock()
+
+		//without anything to run the scheduler waits for new entries
+		var timer = time.NewTimer(next.Sub(now))
+		var fire = timer.C
+		if next.IsZero() {
+			fire = nil
+		}
+		select {
+		case <-fire:
+			for _, f := range due {
+				go f()
+			}
+		case <-s.wake:
+			timer.Stop()
+		case <-stop:
+			timer.Stop()
+			return
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestScheduler(t *testing.T) {
+	var s = NewScheduler()
+	//every call of the clock is a minute later, so every minute is due 10ms after the scheduler looks at the clock
+	var clock = nepal(2024, 4, 13, 9, 59, 59).Add(990 * time.Millisecond)
+	s.now = func() time.Time {
+		clock = clock.Add(time.Minute)
+		return clock
+	}
+	var runs = make(chan string, 10)
+	_, err := s.AddFunc("* * * * *", func() { runs <- "every minute" })
+	assert.Equal(t, err, nil)
+	_, err = s.AddFunc("* * 32 * *", func() { runs <- "never" })
+	assert.Equal(t, err, nil)
+	_, err = s.AddFunc("invalid", func() {})
+	assert.Equal(t, err != nil, true)
+
+	s.Start()
+	s.Start()
+	for i := 0; i < 3; i++ {
+		select {
+		case run := <-runs:
+			assert.Equal(t, run, "every minute")
+		case <-time.After(time.Second):
+			t.Fatal("scheduled function did not run")
+		}
+	}
+	s.Stop()
+	s.Stop()
+}
+
+func TestSchedulerRemove(t *testing.T) {
+	var s = NewScheduler()
+	var clock = nepal(2024, 4, 13, 9, 59, 59).Add(990 * time.Millisecond)
+	s.now = func() time.Time {
+		clock = clock.Add(time.Minute)
+		return clock
+	}
+	var runs = make(chan EntryID, 10)
+	var removed EntryID
+	removed, _ = s.AddFunc("* * * * *", func() { runs <- removed })
+	s.Remove(removed)
+	kept, _ := s.AddFunc("* * * * *", func() { runs <- 0 })
+	s.Start()
+	defer s.Stop()
+	select {
+	case id := <-runs:
+		assert.Equal(t, id, EntryID(0))
+	case <-time.After(time.Second):
+		t.Fatal("scheduled function did not run")
+	}
+	assert.Equal(t, kept, EntryID(2))
+}
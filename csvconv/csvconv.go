@@ -0,0 +1,267 @@
+// Package csvconv adds converted date columns to CSV and TSV files.
+//
+// The input is read and written one row at a time, so files of any size are converted in constant memory.
+// BS dates are read and written with the layouts of bsdate.Format, gregorian dates with the layouts of the time package.
+package csvconv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+)
+
+// Direction tells which calendar a column is converted to
+type Direction int
+
+const (
+	// ToGregorian reads BS dates and writes gregorian dates
+	ToGregorian Direction = iota
+	// ToBS reads gregorian dates and writes BS dates
+	ToBS
+)
+
+// Policy decides what happens with cells that are no valid date
+type Policy int
+
+const (
+	// Fail stops the conversion at the first invalid cell
+	Fail Policy = iota
+	// Blank leaves the converted cell empty
+	Blank
+	// Flag leaves the converted cell empty and writes the reason into an extra column named like the output column
+	// with the suffix "_error"
+	Flag
+)
+
+// maxReportedErrors is the amount of errors that are kept per column, the report counts all of them
+const maxReportedErrors = 10
+
+const (
+	defaultBSLayout        = bsdate.ISOLayout
+	defaultGregorianLayout = "2006-01-02"
+)
+
+// Column describes a column to convert
+type Column struct {
+	// Name is the header of the column to read
+	Name      string
+	Direction Direction
+	// InputLayout defaults to YYYY-MM-DD for BS and 2006-01-02 for gregorian dates
+	InputLayout string
+	// OutputLayout defaults to YYYY-MM-DD for BS and 2006-01-02 for gregorian dates
+	OutputLayout string
+	// Output is the header of the added column, the converted dates replace the input column if it is empty
+	Output string
+}
+
+// Converter converts date columns of a CSV stream, the first row has to be the header
+type Converter struct {
+	Columns []Column
+	// Comma separates the fields, it defaults to ','. Use '\t' for TSV
+	Comma  rune
+	Policy Policy
+}
+
+// CellError describes a cell that could not be converted.
+// Row numbers count the header as row 1, like spreadsheets do
+type CellError struct {
+	Column string
+	Row    int
+	Value  string
+	Err    error
+}
+
+func (e CellError) Error() string {
+	return fmt.Sprintf("%s, row %d: %q: %s", e.Column, e.Row, e.Value, e.Err.Error())
+}
+
+// ColumnReport sums up the conversion of one column, Errors holds only the first errors
+type ColumnReport struct {
+	Column    string
+	Converted int
+	Failed    int
+	Errors    []CellError
+}
+
+// Report sums up a conversion
+type Report struct {
+	Rows    int
+	Columns []ColumnReport
+}
+
+// Failed returns the amount of cells that could not be converted in all columns
+func (r Report) Failed() int {
+	var failed = 0
+	for _, column := range r.Columns {
+		failed += column.Failed
+	}
+	return failed
+}
+
+// plan says what to do with one column of the input
+type plan struct {
+	column  Column
+	report  *ColumnReport
+	replace bool
+}
+
+// Convert reads CSV from r and writes it with the converted columns to w.
+// With the Fail policy the error is a CellError, rows before the invalid one are already written
+func (c *Converter) Convert(r io.Reader, w io.Writer) (Report, error) {
+	var comma = c.Comma
+	if comma == 0 {
+		comma = ','
+	}
+	var reader = csv.NewReader(r)
+	reader.Comma = comma
+	reader.ReuseRecord = true
+	var writer = csv.NewWriter(w)
+	writer.Comma = comma
+
+	var report = Report{Columns: make([]ColumnReport, len(c.Columns))}
+	header, err := reader.Read()
+	if err == io.EOF {
+		return report, errors.New("input is empty, the header is missing")
+	}
+	if err != nil {
+		return report, err
+	}
+	plans, outputHeader, err := c.plan(header, report.Columns)
+	if err != nil {
+		return report, err
+	}
+	if err := writer.Write(outputHeader); err != nil {
+		return report, err
+	}
+
+	var output = make([]string, 0, len(outputHeader))
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return report, err
+		}
+		report.Rows++
+		output = output[:0]
+		for i, value := range record {
+			var p = plans[i]
+			if p == nil {
+				output = append(output, value)
+				continue
+			}
+			converted, convertErr := convert(p.column, value)
+			if convertErr != nil {
+				var cellErr = CellError{Column: p.column.Name, Row: row, Value: value, Err: convertErr}
+				p.report.Failed++
+				if len(p.report.Errors) < maxReportedErrors {
+					p.report.Errors = append(p.report.Errors, cellErr)
+				}
+				if c.Policy == Fail {
+					writer.Flush()
+					return report, cellErr
+				}
+			} else {
+				p.report.Converted++
+			}
+			if !p.replace {
+				output = append(output, value)
+			}
+			output = append(output, converted)
+			if c.Policy == Flag {
+				var reason = ""
+				if convertErr != nil {
+					reason = convertErr.Error()
+				}
+				output = append(output, reason)
+			}
+		}
+		if err := writer.Write(output); err != nil {
+			return report, err
+		}
+	}
+	writer.Flush()
+	return report, writer.Error()
+}
+
+// plan finds the configured columns in the header and returns the header of the output
+func (c *Converter) plan(header []string, reports []ColumnReport) ([]*plan, []string, error) {
+	var plans = make([]*plan, len(header))
+	var outputHeader []string
+	for i, column := range c.Columns {
+		reports[i].Column = column.Name
+		var found = false
+		for j, name := range header {
+			if name != column.Name {
+				continue
+			}
+			if plans[j] != nil {
+				return nil, nil, errors.New("column " + column.Name + " is converted twice")
+			}
+			plans[j] = &plan{column: column, report: &reports[i], replace: column.Output == ""}
+			found = true
+			break
+		}
+		if !found {
+			return nil, nil, errors.New("column " + column.Name + " is not in the header")
+		}
+	}
+	for i, name := range header {
+		var p = plans[i]
+		if p == nil {
+			outputHeader = append(outputHeader, name)
+			continue
+		}
+		var outputName = p.column.Output
+		if p.replace {
+			outputName = name
+		} else {
+			outputHeader = append(outputHeader, name)
+		}
+		outputHeader = append(outputHeader, outputName)
+		if c.Policy == Flag {
+			outputHeader = append(outputHeader, outputName+"_error")
+		}
+	}
+	return plans, outputHeader, nil
+}
+
+// convert returns the converted value, it is empty if value is no valid date
+func convert(column Column, value string) (string, error) {
+	var bsLayout, gregorianLayout = column.InputLayout, column.OutputLayout
+	if column.Direction == ToBS {
+		bsLayout, gregorianLayout = column.OutputLayout, column.InputLayout
+	}
+	if bsLayout == "" {
+		bsLayout = defaultBSLayout
+	}
+	if gregorianLayout == "" {
+		gregorianLayout = defaultGregorianLayout
+	}
+
+	if column.Direction == ToBS {
+		gregorianDate, err := time.Parse(gregorianLayout, value)
+		if err != nil {
+			return "", errors.New("not a date in the layout " + gregorianLayout)
+		}
+		d, err := bsdate.NewFromGregorian(gregorianDate.Day(), int(gregorianDate.Month()), gregorianDate.Year())
+		if err != nil {
+			return "", err
+		}
+		return bsdate.Format(d, bsLayout), nil
+	}
+	d, err := bsdate.Parse(bsLayout, value)
+	if err != nil {
+		return "", err
+	}
+	gregorianDate, err := d.GetGregorianDate()
+	if err != nil {
+		return "", err
+	}
+	return gregorianDate.Format(gregorianLayout), nil
+}
@@ -0,0 +1,96 @@
+package csvconv
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestConvert(t *testing.T) {
+	var input = "name,born,joined\n" +
+		"Sita,2081-01-01,2024-04-13\n" +
+		"Ram,2081-03-32,2024-07-15\n"
+	var c = Converter{Columns: []Column{
+		{Name: "born", Direction: ToGregorian, Output: "born_ad"},
+		{Name: "joined", Direction: ToBS, OutputLayout: "D MMMM YYYY"},
+	}}
+	var output bytes.Buffer
+	report, err := c.Convert(strings.NewReader(input), &output)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, output.String(), "name,born,born_ad,joined\n"+
+		"Sita,2081-01-01,2024-04-13,1 Baisakh 2081\n"+
+		"Ram,2081-03-32,2024-07-15,32 Ashadh 2081\n")
+	assert.Equal(t, report.Rows, 2)
+	assert.Equal(t, report.Columns[0].Column, "born")
+	assert.Equal(t, report.Columns[0].Converted, 2)
+	assert.Equal(t, report.Failed(), 0)
+}
+
+func TestConvertTSV(t *testing.T) {
+	var c = Converter{
+		Columns: []Column{{Name: "date", Direction: ToGregorian, InputLayout: "DD.MM.YYYY", OutputLayout: "02/01/2006", Output: "ad"}},
+		Comma:   '\t',
+	}
+	var output bytes.Buffer
+	_, err := c.Convert(strings.NewReader("id\tdate\n1\t15.02.2081\n"), &output)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, output.String(), "id\tdate\tad\n1\t15.02.2081\t28/05/2024\n")
+}
+
+func TestConvertPolicies(t *testing.T) {
+	var input = "date\n2081-01-01\n2081-02-32\n\n2081-01-02\n"
+	var tests = []struct {
+		policy   Policy
+		expected string
+	}{
+		{Fail, "date,ad\n2081-01-01,2024-04-13\n"},
+		{Blank, "date,ad\n2081-01-01,2024-04-13\n2081-02-32,\n2081-01-02,2024-04-14\n"},
+		{Flag, "date,ad,ad_error\n2081-01-01,2024-04-13,\n" +
+			"2081-02-32,,not a valid date\n" +
+			"2081-01-02,2024-04-14,\n"},
+	}
+	for _, test := range tests {
+		var c = Converter{Columns: []Column{{Name: "date", Output: "ad"}}, Policy: test.policy}
+		var output bytes.Buffer
+		report, err := c.Convert(strings.NewReader(input), &output)
+		assert.Equal(t, output.String(), test.expected)
+		assert.Equal(t, report.Failed(), 1)
+		assert.Equal(t, len(report.Columns[0].Errors), 1)
+		assert.Equal(t, report.Columns[0].Errors[0].Row, 3)
+		if test.policy == Fail {
+			assert.Equal(t, err.Error(), "date, row 3: \"2081-02-32\": not a valid date")
+		} else {
+			assert.Equal(t, err, nil)
+			assert.Equal(t, report.Columns[0].Converted, 2)
+		}
+	}
+}
+
+func TestConvertInvalidInput(t *testing.T) {
+	var tests = []struct {
+		input   string
+		columns []Column
+		err     string
+	}{
+		{"", []Column{{Name: "date"}}, "input is empty, the header is missing"},
+		{"day\n", []Column{{Name: "date"}}, "column date is not in the header"},
+		{"date\n", []Column{{Name: "date"}, {Name: "date"}}, "column date is converted twice"},
+		{"date\n2081-01-01,x\n", []Column{{Name: "date"}}, "record on line 2: wrong number of fields"},
+	}
+	for _, test := range tests {
+		var c = Converter{Columns: test.columns}
+		_, err := c.Convert(strings.NewReader(test.input), &bytes.Buffer{})
+		assert.Equal(t, err.Error(), test.err)
+	}
+}
+
+func TestReportKeepsFirstErrors(t *testing.T) {
+	var input = "date\n" + strings.Repeat("x\n", 25)
+	var c = Converter{Columns: []Column{{Name: "date"}}, Policy: Blank}
+	report, err := c.Convert(strings.NewReader(input), &bytes.Buffer{})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, report.Columns[0].Failed, 25)
+	assert.Equal(t, len(report.Columns[0].Errors), maxReportedErrors)
+}
@@ -0,0 +1,261 @@
+package bsdate
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// YearData is the row of a BS year in the calendar data
+type YearData struct {
+	// JanuaryInPaush is the day of Paush that is the 1st January
+	JanuaryInPaush int
+	// MonthLengths are the days of the months starting with Baisakh
+	MonthLengths [12]int
+}
+
+// calendarTable has the same layout as builtinCalendarData
+type calendarTable map[int][13]int
+
+// calendarSnapshot is a version of the calendar data, it is never changed once it is stored
+type calendarSnapshot struct {
+	version int64
+	years   calendarTable
+	//starts[i] is the ordinal of the 1st Baisakh of firstYear+i, the last entry is the day after the data.
+	//Ordinals count the days from the 1st Baisakh of firstYear, see toOrdinal
+	firstYear int
+	starts    []int
+}
+
+var builtinSnapshot = newSnapshot(1, builtinCalendarData)
+
+// newSnapshot creates a version of the data, the years have to follow each other without a gap
+func newSnapshot(version int64, years calendarTable) *calendarSnapshot {
+	var s = &calendarSnapshot{version: version, years: years}
+	for year := range years {
+		if len(s.starts) == 0 || year < s.firstYear {
+			s.firstYear = year
+			s.starts = []int{0}
+		}
+	}
+	for year := s.firstYear; year < s.firstYear+len(years); year++ {
+		s.starts = append(s.starts, s.starts[len(s.starts)-1]+years.yearLength(year))
+	}
+	return s
+}
+
+// contains reports if d is a day of the data. A date can lose its day when Reset removes its year or an update
+// shortens its month
+func (s *calendarSnapshot) contains(d Date) bool {
+	row, ok := s.years[d.GetYear()]
+	return ok && d.GetMonth() >= 1 && d.GetMonth() <= 12 && d.GetDay() >= 1 && d.GetDay() <= row[d.GetMonth()]
+}
+
+func (s *calendarSnapshot) daysInMonth(year, month int) (int, error) {
+	if month <= 0 || month > 12 {
+		return 0, ErrInvalidDate
+	}
+	if _, ok := s.years[year]; !ok {
+		return 0, ErrMissingData
+	}
+	return s.years[year][month], nil
+}
+
+// snapshot returns the calendar data to use.
+// Functions read it once and use it for all their work, so they see the same version even during an update
+func (c *Calendar) snapshot() *calendarSnapshot {
+	if snapshot, ok := c.value.Load().(*calendarSnapshot); ok {
+		return snapshot
+	}
+	return c.initial
+}
+
+// DataVersion returns the version of the data of the default calendar, see Calendar.Version
+func DataVersion() int64 {
+	return defaultCalendar.Version()
+}
+
+// Version returns the version of the calendar data, it is increased by every update.
+// Caches of converted dates can use it to notice that they are outdated
+func (c *Calendar) Version() int64 {
+	return c.snapshot().version
+}
+
+// LookupYear returns the data of a BS year in the default calendar
+func LookupYear(year int) (YearData, bool) {
+	return defaultCalendar.LookupYear(year)
+}
+
+// LookupYear returns the data of a BS year
+func (c *Calendar) LookupYear(year int) (YearData, bool) {
+	row, ok := c.snapshot().years[year]
+	return toYearData(row), ok
+}
+
+// CalendarData returns a copy of the data of all years of the default calendar
+func CalendarData() map[int]YearData {
+	return defaultCalendar.Data()
+}
+
+// Data returns a copy of the data of all years
+func (c *Calendar) Data() map[int]YearData {
+	var years = map[int]YearData{}
+	for year, row := range c.snapshot().years {
+		years[year] = toYearData(row)
+	}
+	return years
+}
+
+// UpdateCalendarData updates the data of the default calendar, see Calendar.Update
+func UpdateCalendarData(years map[int]YearData) (int64, error) {
+	return defaultCalendar.Update(years)
+}
+
+// Update overrides or adds years, e.g. when corrections are published for upcoming years.
+// The update is checked first and is applied either completely or not at all, dates that are converted at the
+// same time use either the old or the new data. The new version is returned
+func (c *Calendar) Update(years map[int]YearData) (int64, error) {
+	if len(years) == 0 {
+		return c.Version(), errors.New("the update has no years")
+	}
+	c.updateMutex.Lock()
+	defer c.updateMutex.Unlock()
+	var current = c.snapshot()
+	var updated = calendarTable{}
+	for year, row := range current.years {
+		updated[year] = row
+	}
+	var changed = sortedYears(years)
+	for _, year := range changed {
+		if err := checkYear(year, years[year]); err != nil {
+			return current.version, err
+		}
+		updated[year] = fromYearData(years[year])
+	}
+	if err := checkUpdate(updated, changed); err != nil {
+		return current.version, err
+	}
+	var snapshot = newSnapshot(current.version+1, updated)
+	c.value.Store(snapshot)
+	return snapshot.version, nil
+}
+
+// ResetCalendarData undoes all updates of the default calendar and goes back to the data that is built into
+// this module, see Calendar.Reset
+func ResetCalendarData() int64 {
+	return defaultCalendar.Reset()
+}
+
+// Reset undoes all updates and goes back to the data the calendar was created with.
+// The version is increased like by an update
+func (c *Calendar) Reset() int64 {
+	c.updateMutex.Lock()
+	defer c.updateMutex.Unlock()
+	var snapshot = newSnapshot(c.snapshot().version+1, c.initial.years)
+	c.value.Store(snapshot)
+	return snapshot.version
+}
+
+func sortedYears(years map[int]YearData) []int {
+	var sorted []int
+	for year := range years {
+		sorted = append(sorted, year)
+	}
+	sort.Ints(sorted)
+	return sorted
+}
+
+func toYearData(row [13]int) YearData {
+	var data = YearData{JanuaryInPaush: row[0]}
+	copy(data.MonthLengths[:], row[1:])
+	return data
+}
+
+func fromYearData(data YearData) [13]int {
+	var row = [13]int{data.JanuaryInPaush}
+	copy(row[1:], data.MonthLengths[:])
+	return row
+}
+
+// checkYear checks a single year of an update
+func checkYear(year int, data YearData) error {
+	if err := checkRow(year, data); err != nil {
+		return err
+	}
+	var length = 0
+	for _, days := range data.MonthLengths {
+		length += days
+	}
+	if length != 365 && length != 366 {
+		return errors.New("year " + strconv.Itoa(year) + ": has " + strconv.Itoa(length) + " days, a year has 365 or 366 days")
+	}
+	return nil
+}
+
+// checkRow checks that the values of a year can be used for conversions at all
+func checkRow(year int, data YearData) error {
+	var prefix = "year " + strconv.Itoa(year) + ": "
+	if year <= 0 {
+		return errors.New(prefix + "is not a valid year")
+	}
+	for i, days := range data.MonthLengths {
+		if days < 29 || days > 32 {
+			return errors.New(prefix + MonthNames[i] + " has " + strconv.Itoa(days) + " days, a month has 29 to 32 days")
+		}
+	}
+	if data.JanuaryInPaush < 1 || data.JanuaryInPaush > data.MonthLengths[8] {
+		return errors.New(prefix + "1st January has to be a day of Paush")
+	}
+	return nil
+}
+
+// checkUpdate checks that the updated years fit to the years next to them
+func checkUpdate(updated calendarTable, changed []int) error {
+	var first, last = changed[0], changed[0]
+	for year := range updated {
+		if year < first {
+			first = year
+		}
+		if year > last {
+			last = year
+		}
+	}
+	if last-first != len(updated)-1 {
+		return errors.New("the years have to follow each other without a gap")
+	}
+	for _, year := range changed {
+		for _, first := range []int{year - 1, year} {
+			if _, ok := updated[first]; !ok {
+				continue
+			}
+			if _, ok := updated[first+1]; !ok {
+				continue
+			}
+			if err := checkJanuaries(updated, first); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkJanuaries checks that the 1st January in year and the one in the following year are a gregorian year apart
+func checkJanuaries(data calendarTable, year int) error {
+	var days = data[year][9] - data[year][0] + 1
+	for month := 10; month <= 12; month++ {
+		days += data[year][month]
+	}
+	for month := 1; month <= 8; month++ {
+		days += data[year+1][month]
+	}
+	days += data[year+1][0] - 1
+	var gregorianYear = year - 56
+	var expected = time.Date(gregorianYear, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+	if days != expected {
+		return errors.New("years " + strconv.Itoa(year) + " and " + strconv.Itoa(year+1) + ": there are " +
+			strconv.Itoa(days) + " days between the 1st January in Paush of both years, but " +
+			strconv.Itoa(gregorianYear) + " AD has " + strconv.Itoa(expected))
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package bsdate
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+// year2101 follows the data of 2100, the 1st January 2045 is the 18th Paush
+var year2101 = YearData{JanuaryInPaush: 18, MonthLengths: [12]int{31, 31, 32, 31, 31, 31, 30, 29, 30, 29, 30, 30}}
+
+func TestLookupYear(t *testing.T) {
+	data, ok := LookupYear(2081)
+	assert.Equal(t, ok, true)
+	assert.Equal(t, data, YearData{JanuaryInPaush: 17, MonthLengths: [12]int{31, 31, 32, 32, 31, 30, 30, 30, 29, 30, 30, 30}})
+	_, ok = LookupYear(2200)
+	assert.Equal(t, ok, false)
+	assert.Equal(t, len(CalendarData()), 131)
+}
+
+func TestUpdateCalendarData(t *testing.T) {
+	defer ResetCalendarData()
+	var version = DataVersion()
+	_, err := New(1, 1, 2101)
+	assert.Equal(t, err, ErrInvalidDate)
+
+	newVersion, err := UpdateCalendarData(map[int]YearData{2101: year2101})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, newVersion, version+1)
+	assert.Equal(t, DataVersion(), version+1)
+
+	d, err := New(30, 12, 2101)
+	assert.Equal(t, err, nil)
+	gregorianDate, err := d.GetGregorianDate()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, gregorianDate.Format("2006-01-02"), "2045-04-12")
+	d, err = NewFromGregorian(13, 4, 2044)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, formatForTest(d), "2101-01-01")
+	days, err := DaysInMonth(2101, 3)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, days, 32)
+
+	//the projection starts after the new year
+	approx, err := ApproxToGregorian(1, 1, 2101)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, approx.Exact, true)
+	approx, err = ApproxToGregorian(1, 1, 2102)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, approx.Exact, false)
+	assert.Equal(t, approx.Gregorian.Format("2006-01-02"), "2045-04-13")
+
+	//existing years can be overridden with corrected data
+	_, err = UpdateCalendarData(map[int]YearData{2070: CalendarData()[2070]})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, DataVersion(), version+2)
+
+	assert.Equal(t, ResetCalendarData(), version+3)
+	_, ok := LookupYear(2101)
+	assert.Equal(t, ok, false)
+}
+
+func TestDatesWithoutData(t *testing.T) {
+	calendar, err := NewCalendar("updated", CalendarData())
+	assert.Equal(t, err, nil)
+	_, err = calendar.Update(map[int]YearData{2101: year2101})
+	assert.Equal(t, err, nil)
+	added, err := calendar.New(1, 1, 2101)
+	assert.Equal(t, err, nil)
+	lastDay, err := calendar.New(30, 12, 2100)
+	assert.Equal(t, err, nil)
+
+	//the reset removes 2101, the date still exists but cannot be used for calculations anymore
+	calendar.Reset()
+	_, err = added.GetGregorianDate()
+	assert.Equal(t, err, ErrMissingData)
+	_, err = calendar.AddDays(added, 1)
+	assert.Equal(t, err, ErrMissingData)
+	_, err = calendar.DaysBetween(lastDay, added)
+	assert.Equal(t, err, ErrMissingData)
+	_, err = Rule{Freq: Daily}.Iterate(added)
+	assert.Equal(t, err, ErrMissingData)
+}
+
+func TestUpdateCalendarDataInvalid(t *testing.T) {
+	defer ResetCalendarData()
+	var tooLong, januaryOutside, wrongJanuary = year2101, year2101, year2101
+	tooLong.MonthLengths[0], tooLong.MonthLengths[1] = 32, 32
+	januaryOutside.JanuaryInPaush = 31
+	wrongJanuary.JanuaryInPaush = 17
+	var shortMonth = year2101
+	shortMonth.MonthLengths[1], shortMonth.MonthLengths[2] = 28, 35
+
+	var tests = []struct {
+		name     string
+		years    map[int]YearData
+		expected string
+	}{
+		{"empty", map[int]YearData{}, "the update has no years"},
+		{"month", map[int]YearData{2101: shortMonth}, "year 2101: Jestha has 28 days, a month has 29 to 32 days"},
+		{"year", map[int]YearData{2101: tooLong}, "year 2101: has 367 days, a year has 365 or 366 days"},
+		{"paush", map[int]YearData{2101: januaryOutside}, "year 2101: 1st January has to be a day of Paush"},
+		{"gap", map[int]YearData{2102: year2101}, "the years have to follow each other without a gap"},
+		{"january", map[int]YearData{2101: wrongJanuary},
+			"years 2100 and 2101: there are 365 days between the 1st January in Paush of both years, but 2044 AD has 366"},
+		//some years of the built in data do not fit to each other, so they cannot be updated alone
+		{"built in", map[int]YearData{2082: CalendarData()[2082]},
+			"years 2081 and 2082: there are 366 days between the 1st January in Paush of both years, but 2025 AD has 365"},
+		{"year 0", map[int]YearData{0: year2101}, "year 0: is not a valid year"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var version = DataVersion()
+			_, err := UpdateCalendarData(test.years)
+			assert.Equal(t, err.Error(), test.expected)
+			assert.Equal(t, DataVersion(), version)
+		})
+	}
+}
+
+func TestUpdateCalendarDataConcurrently(t *testing.T) {
+	defer ResetCalendarData()
+	var wait sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			for j := 0; j < 200; j++ {
+				d, err := NewFromGregorian(15, 7, 2024)
+				assert.Equal(t, err, nil)
+				assert.Equal(t, formatForTest(d), "2081-03-32")
+				_, _ = ApproxFromGregorian(1, 1, 2050)
+			}
+		}()
+	}
+	for j := 0; j < 50; j++ {
+		_, err := UpdateCalendarData(map[int]YearData{2101: year2101})
+		assert.Equal(t, err, nil)
+		ResetCalendarData()
+	}
+	wait.Wait()
+}
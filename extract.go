@@ -0,0 +1,269 @@
+package bsdate
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Match is a BS date found by Extract
+type Match struct {
+	// Start and End are byte offsets, the date is written in text[Start:End]
+	Start, End int
+	Date       Date
+	// Confidence is between 0 and 1. Dates with month names or words like साल and गते get a high confidence,
+	// dates written only with numbers a low one, because they might be gregorian dates.
+	// A marker like "वि.सं." or "B.S." next to the date raises the confidence
+	Confidence float64
+}
+
+const (
+	confidenceWithMarkerWords = 0.95
+	confidenceWithMonthName   = 0.85
+	confidenceYearFirst       = 0.6
+	confidenceYearLast        = 0.5
+	confidenceEraBonus        = 0.3
+)
+
+// eraMarkers are written before or after BS dates
+var eraMarkers = []string{"वि.सं.", "वि. सं.", "वि सं", "बि.सं.", "B.S.", "B.S", "BS"}
+
+type extractToken struct {
+	start, end int
+	text       string
+	//digits is the amount of digits of a number, 0 for other tokens
+	digits int
+	number int
+	space  bool
+}
+
+// Extract finds all BS dates in a text written in Nepali or English, e.g. "२०८१ साल असार ३२ गते",
+// "32 Ashadh 2081", "2081/03/32" or "वि.सं. २०८१-३-३२". Month names of all registered locales are understood.
+// Only dates that exist are returned, e.g. Ashadh 32 only in years in which Ashadh has 32 days.
+// The matches are in the order in which they appear in the text
+func Extract(text string) []Match {
+	var tokens = tokenize(text)
+	var matches []Match
+	for i := 0; i < len(tokens); i++ {
+		for _, pattern := range extractPatterns {
+			end, day, month, year, confidence, ok := pattern(tokens, i)
+			if !ok {
+				continue
+			}
+			d, err := New(day, month, year)
+			if err != nil {
+				continue
+			}
+			var match = Match{Start: tokens[i].start, End: tokens[end].end, Date: d, Confidence: confidence}
+			if hasEraMarker(text, match.Start, match.End) {
+				match.Confidence += confidenceEraBonus
+				if match.Confidence > 1 {
+					match.Confidence = 1
+				}
+			}
+			matches = append(matches, match)
+			i = end
+			break
+		}
+	}
+	return matches
+}
+
+// tokenize splits the text into numbers, words, spaces and single other characters
+func tokenize(text string) []extractToken {
+	var tokens []extractToken
+	for position := 0; position < len(text); {
+		var character, size = utf8.DecodeRuneInString(text[position:])
+		var token = extractToken{start: position}
+		var end = position + size
+		switch {
+		case digitValue(character) >= 0:
+			for end = position; end < len(text); end += size {
+				character, size = utf8.DecodeRuneInString(text[end:])
+				var digit = digitValue(character)
+				if digit < 0 {
+					break
+				}
+				token.number = token.number*10 + digit
+				token.digits++
+			}
+		case isWordCharacter(character):
+			for end = position; end < len(text); end += size {
+				character, size = utf8.DecodeRuneInString(text[end:])
+				if !isWordCharacter(character) {
+					break
+				}
+			}
+		case unicode.IsSpace(character):
+			token.space = true
+			for end = position; end < len(text); end += size {
+				character, size = utf8.DecodeRuneInString(text[end:])
+				if !unicode.IsSpace(character) {
+					break
+				}
+			}
+		}
+		token.end = end
+		token.text = text[position:end]
+		tokens = append(tokens, token)
+		position = end
+	}
+	return tokens
+}
+
+// digitValue returns the value of 0-9 and ०-९, -1 for other characters
+func digitValue(character rune) int {
+	switch {
+	case character >= '0' && character <= '9':
+		return int(character - '0')
+	case character >= '०' && character <= '९':
+		return int(character - '०')
+	}
+	return -1
+}
+
+func isWordCharacter(character rune) bool {
+	if character == '।' || character == '॥' {
+		return false
+	}
+	return unicode.IsLetter(character) || unicode.Is(unicode.Mn, character) || unicode.Is(unicode.Mc, character)
+}
+
+func hasEraMarker(text string, start, end int) bool {
+	var before, after = strings.TrimRight(text[:start], " "), strings.TrimLeft(text[end:], " ")
+	for _, marker := range eraMarkers {
+		if strings.HasSuffix(before, marker) || strings.HasPrefix(after, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// an extractPattern tries to read a date from the token at i on, end is the index of its last token
+type extractPattern func(tokens []extractToken, i int) (end, day, month, year int, confidence float64, ok bool)
+
+var extractPatterns = []extractPattern{yearMonthDayWords, dayMonthYearWords, monthDayYearWords, yearFirstNumbers, yearLastNumbers}
+
+// skipSpace returns the index of the next token that is no space
+func skipSpace(tokens []extractToken, i int) int {
+	for i < len(tokens) && tokens[i].space {
+		i++
+	}
+	return i
+}
+
+func isNumber(tokens []extractToken, i, minDigits, maxDigits int) bool {
+	return i < len(tokens) && tokens[i].digits >= minDigits && tokens[i].digits <= maxDigits
+}
+
+func isWord(tokens []extractToken, i int, word string) bool {
+	return i < len(tokens) && tokens[i].text == word
+}
+
+func monthAt(tokens []extractToken, i int) int {
+	if i >= len(tokens) || tokens[i].digits > 0 || tokens[i].space {
+		return 0
+	}
+	month, _ := ResolveMonthName(tokens[i].text)
+	return month
+}
+
+// yearMonthDayWords reads "२०८१ साल असार ३२ गते" or "2081 Ashadh 32", साल and गते are optional
+func yearMonthDayWords(tokens []extractToken, i int) (int, int, int, int, float64, bool) {
+	if !isNumber(tokens, i, 4, 4) {
+		return 0, 0, 0, 0, 0, false
+	}
+	var confidence = confidenceWithMonthName
+	var next = skipSpace(tokens, i+1)
+	if isWord(tokens, next, "साल") {
+		confidence = confidenceWithMarkerWords
+		next = skipSpace(tokens, next+1)
+	}
+	var month = monthAt(tokens, next)
+	var dayIndex = skipSpace(tokens, next+1)
+	if month == 0 || !isNumber(tokens, dayIndex, 1, 2) {
+		return 0, 0, 0, 0, 0, false
+	}
+	var end = dayIndex
+	if gate := skipSpace(tokens, dayIndex+1); isWord(tokens, gate, "गते") {
+		confidence = confidenceWithMarkerWords
+		end = gate
+	}
+	return end, tokens[dayIndex].number, month, tokens[i].number, confidence, true
+}
+
+// dayMonthYearWords reads "32 Ashadh 2081" or "32 Ashadh, 2081"
+func dayMonthYearWords(tokens []extractToken, i int) (int, int, int, int, float64, bool) {
+	if !isNumber(tokens, i, 1, 2) {
+		return 0, 0, 0, 0, 0, false
+	}
+	var next = skipSpace(tokens, i+1)
+	if isWord(tokens, next, "गते") {
+		next = skipSpace(tokens, next+1)
+	}
+	var month = monthAt(tokens, next)
+	var yearIndex = skipComma(tokens, next+1)
+	if month == 0 || !isNumber(tokens, yearIndex, 4, 4) {
+		return 0, 0, 0, 0, 0, false
+	}
+	return yearIndex, tokens[i].number, month, tokens[yearIndex].number, confidenceWithMonthName, true
+}
+
+// monthDayYearWords reads "Ashadh 32, 2081"
+func monthDayYearWords(tokens []extractToken, i int) (int, int, int, int, float64, bool) {
+	var month = monthAt(tokens, i)
+	var dayIndex = skipSpace(tokens, i+1)
+	if month == 0 || !isNumber(tokens, dayIndex, 1, 2) {
+		return 0, 0, 0, 0, 0, false
+	}
+	var yearIndex = skipComma(tokens, dayIndex+1)
+	if !isNumber(tokens, yearIndex, 4, 4) {
+		return 0, 0, 0, 0, 0, false
+	}
+	return yearIndex, tokens[dayIndex].number, month, tokens[yearIndex].number, confidenceWithMonthName, true
+}
+
+func skipComma(tokens []extractToken, i int) int {
+	i = skipSpace(tokens, i)
+	if isWord(tokens, i, ",") {
+		i = skipSpace(tokens, i+1)
+	}
+	return i
+}
+
+// numbers reads three numbers separated by the same "/", "-" or ".", e.g. 2081/03/32
+func numbers(tokens []extractToken, i int) ([3]int, [3]int, bool) {
+	var values, digits [3]int
+	if i+4 >= len(tokens) {
+		return values, digits, false
+	}
+	var separator = tokens[i+1].text
+	if separator != "/" && separator != "-" && separator != "." || tokens[i+3].text != separator {
+		return values, digits, false
+	}
+	for part := 0; part < 3; part++ {
+		if tokens[i+2*part].digits == 0 {
+			return values, digits, false
+		}
+		values[part], digits[part] = tokens[i+2*part].number, tokens[i+2*part].digits
+	}
+	return values, digits, true
+}
+
+// yearFirstNumbers reads "2081/03/32" or "२०८१-३-३२"
+func yearFirstNumbers(tokens []extractToken, i int) (int, int, int, int, float64, bool) {
+	values, digits, ok := numbers(tokens, i)
+	if !ok || digits[0] != 4 || digits[1] > 2 || digits[2] > 2 {
+		return 0, 0, 0, 0, 0, false
+	}
+	return i + 4, values[2], values[1], values[0], confidenceYearFirst, true
+}
+
+// yearLastNumbers reads "32/03/2081"
+func yearLastNumbers(tokens []extractToken, i int) (int, int, int, int, float64, bool) {
+	values, digits, ok := numbers(tokens, i)
+	if !ok || digits[2] != 4 || digits[0] > 2 || digits[1] > 2 {
+		return 0, 0, 0, 0, 0, false
+	}
+	return i + 4, values[0], values[1], values[2], confidenceYearLast, true
+}
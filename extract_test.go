@@ -0,0 +1,67 @@
+package bsdate
+
+import (
+	"math"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestExtract(t *testing.T) {
+	var tests = []struct {
+		text       string
+		matched    string
+		date       string
+		confidence float64
+	}{
+		{"मिति २०८१ साल असार ३२ गते देखि", "२०८१ साल असार ३२ गते", "2081-03-32", confidenceWithMarkerWords},
+		{"on 32 Ashadh 2081 the court", "32 Ashadh 2081", "2081-03-32", confidenceWithMonthName},
+		{"Ashadh 32, 2081", "Ashadh 32, 2081", "2081-03-32", confidenceWithMonthName},
+		{"२०८१ जेठ १५", "२०८१ जेठ १५", "2081-02-15", confidenceWithMonthName},
+		{"filed 2081/03/32.", "2081/03/32", "2081-03-32", confidenceYearFirst},
+		{"32.03.2081", "32.03.2081", "2081-03-32", confidenceYearLast},
+		{"वि.सं. २०८१-३-३२ मा", "२०८१-३-३२", "2081-03-32", confidenceYearFirst + confidenceEraBonus},
+		{"2081-03-32 B.S.", "2081-03-32", "2081-03-32", confidenceYearFirst + confidenceEraBonus},
+		{"१५ गते बैशाख २०८१", "१५ गते बैशाख २०८१", "2081-01-15", confidenceWithMonthName},
+		{"BS 2081 saun 1", "2081 saun 1", "2081-04-01", 1},
+	}
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			var matches = Extract(test.text)
+			assert.Equal(t, len(matches), 1)
+			assert.Equal(t, test.text[matches[0].Start:matches[0].End], test.matched)
+			assert.Equal(t, formatForTest(matches[0].Date), test.date)
+			assert.Equal(t, math.Abs(matches[0].Confidence-test.confidence) < 1e-9, true)
+		})
+	}
+}
+
+func TestExtractSeveralDates(t *testing.T) {
+	var text = "२०८१ साल असार ३२ गते र 1 Shrawan 2081, तर 2081/04/01 होइन"
+	var matches = Extract(text)
+	assert.Equal(t, len(matches), 3)
+	var found []string
+	for _, match := range matches {
+		found = append(found, formatForTest(match.Date))
+	}
+	assert.Equal(t, found, []string{"2081-03-32", "2081-04-01", "2081-04-01"})
+	assert.Equal(t, text[matches[1].Start:matches[1].End], "1 Shrawan 2081")
+}
+
+func TestExtractRejectsImpossibleDates(t *testing.T) {
+	var texts = []string{
+		"32 Jestha 2081", //Jestha 2081 has 31 days
+		"2080/03/32",     //Ashadh 2080 has 31 days
+		"2081/13/01",
+		"15 Baisakh 2200", //no data
+		"12081/03/15",
+		"2081 Foo 15",
+		"2081/03-15",
+		"just text",
+	}
+	for _, text := range texts {
+		t.Run(text, func(t *testing.T) {
+			assert.Equal(t, len(Extract(text)), 0)
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package bsdate
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ISOLayout writes dates like 2081-01-15, the layout all tools of this module use by default
+const ISOLayout = "YYYY-MM-DD"
+
+// layout tokens, longer tokens have to come first
+var layoutTokens = []string{"YYYY", "MMMM", "MMM", "MM", "dddd", "ddd", "DD", "M", "D"}
+
+// Format writes the date using a layout with these tokens:
+//
+//	YYYY  year, four digits
+//	MMMM  name of the month, e.g. Baisakh
+//	MMM   short name of the month, e.g. Bai
+//	MM    month with two digits
+//	M     month without leading zero
+//	DD    day with two digits
+//	D     day without leading zero
+//	dddd  name of the weekday, e.g. Sunday
+//	ddd   short name of the weekday, e.g. Sun
+//
+// Everything else is copied as it is. Format uses the English locale, see FormatLocale
+func Format(d Date, layout string) string {
+	return FormatLocale(d, layout, English)
+}
+
+// FormatLocale is Format with the names and digits of a locale
+func FormatLocale(d Date, layout string, locale *Locale) string {
+	locale = orEnglish(locale)
+	var result strings.Builder
+	var number = func(n, width int) {
+		result.WriteString(locale.toLocalDigits(padNumber(n, width)))
+	}
+	for len(layout) > 0 {
+		var token = nextLayoutToken(layout)
+		switch token {
+		case "YYYY":
+			number(d.GetYear(), 4)
+		case "MMMM":
+			result.WriteString(locale.MonthNames[d.GetMonth()-1])
+		case "MMM":
+			result.WriteString(locale.shortMonthName(d.GetMonth()))
+		case "MM":
+			number(d.GetMonth(), 2)
+		case "M":
+			number(d.GetMonth(), 1)
+		case "DD":
+			number(d.GetDay(), 2)
+		case "D":
+			number(d.GetDay(), 1)
+		case "dddd":
+			result.WriteString(locale.WeekdayNames[Weekday(d)])
+		case "ddd":
+			result.WriteString(locale.ShortWeekdayNames[Weekday(d)])
+		default:
+			result.WriteString(token)
+		}
+		layout = layout[len(token):]
+	}
+	return result.String()
+}
+
+// Parse reads a date written in the given layout, see Format for the tokens.
+// Month and weekday names are matched case insensitive, all names and aliases of the English locale are understood
+func Parse(layout, value string) (Date, error) {
+	return ParseLocale(layout, value, English)
+}
+
+// ParseLocale is Parse with the names of a locale. Numbers can be written with the digits of the locale or with 0-9
+func ParseLocale(layout, value string, locale *Locale) (Date, error) {
+	locale = orEnglish(locale)
+	var day, month, year = -1, -1, -1
+	var weekday = -1
+	var original = value
+	value = locale.toASCIIDigits(value)
+	for len(layout) > 0 {
+		var token = nextLayoutToken(layout)
+		layout = layout[len(token):]
+		var err error
+		switch token {
+		case "YYYY":
+			year, value, err = readNumber(value, 4, 4)
+		case "MM", "DD":
+			var number int
+			number, value, err = readNumber(value, 2, 2)
+			if token == "MM" {
+				month = number
+			} else {
+				day = number
+			}
+		case "M":
+			month, value, err = readNumber(value, 1, 2)
+		case "D":
+			day, value, err = readNumber(value, 1, 2)
+		case "MMMM", "MMM":
+			month, value, err = readMonthName(value, locale)
+		case "dddd", "ddd":
+			weekday, value, err = readWeekdayName(value, locale)
+		default:
+			if !strings.HasPrefix(value, token) {
+				err = errors.New("expected \"" + token + "\"")
+			}
+			value = strings.TrimPrefix(value, token)
+		}
+		if err != nil {
+			return nil, errors.New("cannot parse \"" + original + "\": " + err.Error())
+		}
+	}
+	if value != "" {
+		return nil, errors.New("cannot parse \"" + original + "\": unexpected \"" + value + "\" at the end")
+	}
+	if day < 0 || month < 0 || year < 0 {
+		return nil, errors.New("cannot parse \"" + original + "\": layout needs a day, a month and a year")
+	}
+	d, err := New(day, month, year)
+	if err != nil {
+		return nil, err
+	}
+	if weekday >= 0 && int(Weekday(d)) != weekday {
+		return nil, errors.New("cannot parse \"" + original + "\": the date is a " + locale.WeekdayNames[Weekday(d)])
+	}
+	return d, nil
+}
+
+// Today returns the current date in Nepal
+func Today() (Date, error) {
+	return defaultCalendar.Today()
+}
+
+// Today returns the current date in Nepal in the calendar
+func (c *Calendar) Today() (Date, error) {
+	var now = time.Now().In(NepalTime)
+	return c.FromGregorian(now.Day(), int(now.Month()), now.Year())
+}
+
+// nextLayoutToken returns the token the layout starts with or a single byte of literal text
+func nextLayoutToken(layout string) string {
+	for _, token := range layoutTokens {
+		if strings.HasPrefix(layout, token) {
+			return token
+		}
+	}
+	return layout[:1]
+}
+
+func padNumber(number, width int) string {
+	var text = strconv.Itoa(number)
+	for len(text) < width {
+		text = "0" + text
+	}
+	return text
+}
+
+// readNumber reads between min and max digits from the start of value
+func readNumber(value string, min, max int) (int, string, error) {
+	var length = 0
+	for length < len(value) && length < max && value[length] >= '0' && value[length] <= '9' {
+		length++
+	}
+	if length < min {
+		return 0, value, errors.New("expected a number at \"" + value + "\"")
+	}
+	number, err := strconv.Atoi(value[:length])
+	return number, value[length:], err
+}
+
+// readMonthName reads the longest month name of the locale the value starts with
+func readMonthName(value string, locale *Locale) (int, string, error) {
+	for _, name := range locale.monthNames() {
+		if hasPrefixFold(value, name.name) {
+			return name.month, value[len(name.name):], nil
+		}
+	}
+	return 0, value, errors.New("expected a month name at \"" + value + "\"")
+}
+
+func readWeekdayName(value string, locale *Locale) (int, string, error) {
+	var found, length = -1, 0
+	for weekday := 0; weekday < 7; weekday++ {
+		for _, name := range []string{locale.WeekdayNames[weekday], locale.ShortWeekdayNames[weekday]} {
+			if name != "" && len(name) > length && hasPrefixFold(value, name) {
+				found, length = weekday, len(name)
+			}
+		}
+	}
+	if found < 0 {
+		return 0, value, errors.New("expected a weekday at \"" + value + "\"")
+	}
+	return found, value[length:], nil
+}
+
+func hasPrefixFold(value, prefix string) bool {
+	return len(value) >= len(prefix) && strings.EqualFold(value[:len(prefix)], prefix)
+}
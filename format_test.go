@@ -0,0 +1,113 @@
+package bsdate
+
+import (
+	"github.com/magiconair/properties/assert"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	var tests = []struct {
+		date     string
+		layout   string
+		expected string
+	}{
+		{"2081-01-05", ISOLayout, "2081-01-05"},
+		{"2081-01-05", "D MMMM YYYY", "5 Baisakh 2081"},
+		{"2081-12-30", "YYYY/M/D", "2081/12/30"},
+		{"2081-03-32", "DD.MM.YYYY BS", "32.03.2081 BS"},
+	}
+	for _, test := range tests {
+		t.Run(test.layout, func(t *testing.T) {
+			assert.Equal(t, Format(mustNew(t, test.date), test.layout), test.expected)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	var tests = []struct {
+		layout   string
+		value    string
+		expected string
+	}{
+		{ISOLayout, "2081-01-05", "2081-01-05"},
+		{"D MMMM YYYY", "5 baisakh 2081", "2081-01-05"},
+		{"YYYY/M/D", "2081/12/30", "2081-12-30"},
+		{"DD.MM.YYYY BS", "32.03.2081 BS", "2081-03-32"},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			d, err := Parse(test.layout, test.value)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, formatForTest(d), test.expected)
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	var tests = []struct {
+		layout string
+		value  string
+	}{
+		{ISOLayout, "2081-1-05"},
+		{ISOLayout, "2081-01-05x"},
+		{ISOLayout, "2081/01/05"},
+		{ISOLayout, "2081-02-32"},
+		{"D MMMM YYYY", "5 Maagh 2081"},
+		{"MM-DD", "01-05"},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			_, err := Parse(test.layout, test.value)
+			assert.Equal(t, err != nil, true)
+		})
+	}
+}
+
+func TestFormatLocale(t *testing.T) {
+	var tests = []struct {
+		layout   string
+		locale   *Locale
+		expected string
+	}{
+		{"ddd, D MMM YYYY", English, "Sat, 1 Bai 2081"},
+		{"dddd", English, "Saturday"},
+		{ISOLayout, Nepali, "२०८१-०१-०१"},
+		{"YYYY MMMM D, dddd", Nepali, "२०८१ बैशाख १, शनिबार"},
+		{"MMM", Nepali, "बैशाख"},
+		{"D MMMM YYYY ddd", Bhojpuri, "१ बैसाख २०८१ सनिचर"},
+		{"D MMMM YYYY", nil, "1 Baisakh 2081"},
+	}
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			assert.Equal(t, FormatLocale(mustNew(t, "2081-01-01"), test.layout, test.locale), test.expected)
+		})
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	var tests = []struct {
+		layout   string
+		value    string
+		locale   *Locale
+		expected string
+	}{
+		{"YYYY MMMM D", "२०८१ जेठ ५", Nepali, "2081-02-05"},
+		{"YYYY MMMM D", "2081 ज्येष्ठ 5", Nepali, "2081-02-05"},
+		{"D MMMM YYYY", "5 baishakh 2081", English, "2081-01-05"},
+		{"ddd, D MMM YYYY", "Sat, 1 Bai 2081", English, "2081-01-01"},
+		{"dddd D MMMM YYYY", "शनिवार १ वैशाख २०८१", Hindi, "2081-01-01"},
+		{"D MMMM YYYY", "5 Baisakh 2081", nil, "2081-01-05"},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			d, err := ParseLocale(test.layout, test.value, test.locale)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, formatForTest(d), test.expected)
+		})
+	}
+
+	_, err := Parse("dddd D MMMM YYYY", "Sunday 1 Baisakh 2081")
+	assert.Equal(t, err.Error(), "cannot parse \"Sunday 1 Baisakh 2081\": the date is a Saturday")
+	_, err = ParseLocale("D MMMM YYYY", "1 Baisakh 2081", Nepali)
+	assert.Equal(t, err.Error(), "cannot parse \"1 Baisakh 2081\": expected a month name at \"Baisakh 2081\"")
+}
@@ -0,0 +1,215 @@
+package bsdate
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GridOptions change how MonthGrid and YearGrid look
+type GridOptions struct {
+	// ShowGregorian adds a line with the gregorian days under every week
+	ShowGregorian bool
+	// Devanagari writes all numbers with Devanagari digits
+	Devanagari bool
+	// Highlight colors Saturdays, the weekly holiday, using ANSI escape codes
+	Highlight bool
+}
+
+const (
+	gridWidth        = 20
+	gridHighlight    = "\x1b[31m"
+	gridDim          = "\x1b[2m"
+	gridResetColor   = "\x1b[0m"
+	gridWeekdayNames = "Su Mo Tu We Th Fr Sa"
+)
+
+// ToDevanagariDigits replaces the digits 0-9 in s by ०-९
+func ToDevanagariDigits(s string) string {
+	var result strings.Builder
+	for _, character := range s {
+		if character >= '0' && character <= '9' {
+			character = '०' + character - '0'
+		}
+		result.WriteRune(character)
+	}
+	return result.String()
+}
+
+// MonthWeeks returns the weeks of a BS month of the default calendar, see Calendar.MonthWeeks
+func MonthWeeks(year, month int) ([][7]int, error) {
+	return defaultCalendar.MonthWeeks(year, month)
+}
+
+// MonthWeeks returns the days of a BS month arranged in weeks from Sunday to Saturday,
+// days of the previous and the next month are 0
+func (c *Calendar) MonthWeeks(year, month int) ([][7]int, error) {
+	first, err := c.New(1, month, year)
+	if err != nil {
+		return nil, err
+	}
+	days, _ := c.DaysInMonth(year, month)
+	var weeks [][7]int
+	var week [7]int
+	var weekday = int(Weekday(first))
+	for day := 1; day <= days; day++ {
+		week[weekday] = day
+		weekday++
+		if weekday == 7 || day == days {
+			weeks = append(weeks, week)
+			week = [7]int{}
+			weekday = 0
+		}
+	}
+	return weeks, nil
+}
+
+// MonthGrid prints a BS month of the default calendar, see Calendar.MonthGrid
+func MonthGrid(year, month int, options GridOptions) (string, error) {
+	return defaultCalendar.MonthGrid(year, month, options)
+}
+
+// MonthGrid prints a BS month like the unix cal command
+//
+//	    Baisakh 2081
+//	Su Mo Tu We Th Fr Sa
+//	                   1
+//	 2  3  4  5  6  7  8
+//	...
+func (c *Calendar) MonthGrid(year, month int, options GridOptions) (string, error) {
+	lines, err := c.monthGridLines(year, month, options)
+	if err != nil {
+		return "", err
+	}
+	var output strings.Builder
+	for _, line := range lines {
+		output.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+	return output.String(), nil
+}
+
+// YearGrid prints a BS year of the default calendar, see Calendar.YearGrid
+func YearGrid(year int, options GridOptions) (string, error) {
+	return defaultCalendar.YearGrid(year, options)
+}
+
+// YearGrid prints all months of a BS year, three months next to each other
+func (c *Calendar) YearGrid(year int, options GridOptions) (string, error) {
+	if _, err := c.DaysInMonth(year, 1); err != nil {
+		return "", err
+	}
+	var output strings.Builder
+	var yearTitle = strconv.Itoa(year)
+	if options.Devanagari {
+		yearTitle = ToDevanagariDigits(yearTitle)
+	}
+	output.WriteString(strings.TrimRight(center(yearTitle, 3*gridWidth+4), " ") + "\n\n")
+	for row := 0; row < 4; row++ {
+		var blocks [3][]string
+		var height = 0
+		for column := 0; column < 3; column++ {
+			lines, err := c.monthGridLines(year, row*3+column+1, options)
+			if err != nil {
+				return "", err
+			}
+			blocks[column] = lines
+			if len(lines) > height {
+				height = len(lines)
+			}
+		}
+		for line := 0; line < height; line++ {
+			var parts [3]string
+			for column, block := range blocks {
+				parts[column] = strings.Repeat(" ", gridWidth)
+				if line < len(block) {
+					parts[column] = block[line]
+				}
+			}
+			output.WriteString(strings.TrimRight(strings.Join(parts[:], "  "), " ") + "\n")
+		}
+		if row < 3 {
+			output.WriteString("\n")
+		}
+	}
+	return output.String(), nil
+}
+
+// monthGridLines returns the lines of a month, every line is gridWidth characters wide, not counting color codes
+func (c *Calendar) monthGridLines(year, month int, options GridOptions) ([]string, error) {
+	weeks, err := c.MonthWeeks(year, month)
+	if err != nil {
+		return nil, err
+	}
+	var firstGregorian time.Time
+	if options.ShowGregorian {
+		first, _ := c.New(1, month, year)
+		if firstGregorian, err = first.GetGregorianDate(); err != nil {
+			return nil, err
+		}
+	}
+	var number = func(n int) string {
+		if options.Devanagari {
+			return ToDevanagariDigits(strconv.Itoa(n))
+		}
+		return strconv.Itoa(n)
+	}
+
+	var lines = []string{center(MonthNames[month-1]+" "+number(year), gridWidth)}
+	if options.ShowGregorian {
+		days, _ := c.DaysInMonth(year, month)
+		var lastGregorian = firstGregorian.AddDate(0, 0, days-1)
+		var title = firstGregorian.Format("Jan") + "/" + lastGregorian.Format("Jan") + " " + number(lastGregorian.Year())
+		if firstGregorian.Year() != lastGregorian.Year() {
+			title = firstGregorian.Format("Jan") + " " + number(firstGregorian.Year()) + "/" +
+				lastGregorian.Format("Jan") + " " + number(lastGregorian.Year())
+		}
+		lines = append(lines, center(title, gridWidth))
+	}
+	if options.Highlight {
+		lines = append(lines, gridWeekdayNames[:18]+gridHighlight+gridWeekdayNames[18:]+gridResetColor)
+	} else {
+		lines = append(lines, gridWeekdayNames)
+	}
+	for _, week := range weeks {
+		var days, gregorianDays [7]string
+		for weekday, day := range week {
+			days[weekday], gregorianDays[weekday] = "  ", "  "
+			if day == 0 {
+				continue
+			}
+			days[weekday] = padLeft(number(day), 2)
+			if options.Highlight && weekday == int(time.Saturday) {
+				days[weekday] = gridHighlight + days[weekday] + gridResetColor
+			}
+			if options.ShowGregorian {
+				gregorianDays[weekday] = padLeft(number(firstGregorian.AddDate(0, 0, day-1).Day()), 2)
+				if options.Highlight {
+					gregorianDays[weekday] = gridDim + gregorianDays[weekday] + gridResetColor
+				}
+			}
+		}
+		lines = append(lines, strings.Join(days[:], " "))
+		if options.ShowGregorian {
+			lines = append(lines, strings.Join(gregorianDays[:], " "))
+		}
+	}
+	return lines, nil
+}
+
+// center pads text on both sides to the width, it counts characters not bytes
+func center(text string, width int) string {
+	var length = len([]rune(text))
+	if length >= width {
+		return text
+	}
+	var left = (width - length) / 2
+	return strings.Repeat(" ", left) + text + strings.Repeat(" ", width-length-left)
+}
+
+func padLeft(text string, width int) string {
+	var length = len([]rune(text))
+	if length >= width {
+		return text
+	}
+	return strings.Repeat(" ", width-length) + text
+}
@@ -0,0 +1,94 @@
+package bsdate
+
+import (
+	"github.com/magiconair/properties/assert"
+	"strings"
+	"testing"
+)
+
+func TestToDevanagariDigits(t *testing.T) {
+	assert.Equal(t, ToDevanagariDigits("2081-01-15"), "२०८१-०१-१५")
+	assert.Equal(t, ToDevanagariDigits("Baisakh 9"), "Baisakh ९")
+}
+
+func TestMonthWeeks(t *testing.T) {
+	weeks, err := MonthWeeks(2081, 1)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(weeks), 6)
+	assert.Equal(t, weeks[0], [7]int{0, 0, 0, 0, 0, 0, 1}) //1st Baisakh 2081 is a Saturday
+	assert.Equal(t, weeks[5], [7]int{30, 31, 0, 0, 0, 0, 0})
+
+	_, err = MonthWeeks(2081, 13)
+	assert.Equal(t, err, ErrInvalidDate)
+}
+
+func TestMonthGrid(t *testing.T) {
+	grid, err := MonthGrid(2081, 1, GridOptions{})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, grid, "    Baisakh 2081\n"+
+		"Su Mo Tu We Th Fr Sa\n"+
+		"                   1\n"+
+		" 2  3  4  5  6  7  8\n"+
+		" 9 10 11 12 13 14 15\n"+
+		"16 17 18 19 20 21 22\n"+
+		"23 24 25 26 27 28 29\n"+
+		"30 31\n")
+}
+
+func TestMonthGridWithOptions(t *testing.T) {
+	grid, err := MonthGrid(2081, 9, GridOptions{ShowGregorian: true, Devanagari: true})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, grid, "     Paush २०८१\n"+
+		" Dec २०२४/Jan २०२५\n"+
+		"Su Mo Tu We Th Fr Sa\n"+
+		"    १  २  ३  ४  ५  ६\n"+
+		"   १६ १७ १८ १९ २० २१\n"+
+		" ७  ८  ९ १० ११ १२ १३\n"+
+		"२२ २३ २४ २५ २६ २७ २८\n"+
+		"१४ १५ १६ १७ १८ १९ २०\n"+
+		"२९ ३० ३१  १  २  ३  ४\n"+
+		"२१ २२ २३ २४ २५ २६ २७\n"+
+		" ५  ६  ७  ८  ९ १० ११\n"+
+		"२८ २९\n"+
+		"१२ १३\n")
+
+	grid, err = MonthGrid(2081, 1, GridOptions{Highlight: true})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, strings.Split(grid, "\n")[3], " 2  3  4  5  6  7 \x1b[31m 8\x1b[0m")
+
+	_, err = MonthGrid(2200, 1, GridOptions{})
+	assert.Equal(t, err, ErrInvalidDate)
+}
+
+func TestYearGrid(t *testing.T) {
+	grid, err := YearGrid(2081, GridOptions{})
+	assert.Equal(t, err, nil)
+	var lines = strings.Split(grid, "\n")
+	assert.Equal(t, lines[0], "                              2081")
+	assert.Equal(t, lines[2], "    Baisakh 2081          Jestha 2081           Ashadh 2081")
+	assert.Equal(t, lines[4], "                   1         1  2  3  4  5                  1  2")
+	assert.Equal(t, lines[9], "30 31                                       31 32")
+	assert.Equal(t, lines[10], "")
+	assert.Equal(t, strings.Count(grid, "Su Mo Tu We Th Fr Sa"), 12)
+
+	_, err = YearGrid(2200, GridOptions{})
+	assert.Equal(t, err, ErrMissingData)
+}
+
+func TestGridOfVendorCalendar(t *testing.T) {
+	var vendor = vendorCalendar(t)
+	weeks, err := vendor.MonthWeeks(2081, 2)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, weeks[0], [7]int{0, 1, 2, 3, 4, 5, 6})
+	assert.Equal(t, weeks[len(weeks)-1], [7]int{28, 29, 30, 31, 32, 0, 0})
+
+	grid, err := vendor.MonthGrid(2081, 1, GridOptions{ShowGregorian: true})
+	assert.Equal(t, err, nil)
+	var lines = strings.Split(grid, "\n")
+	assert.Equal(t, lines[len(lines)-3], "30")
+	assert.Equal(t, lines[len(lines)-2], "12")
+
+	grid, err = vendor.YearGrid(2081, GridOptions{})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, strings.Split(grid, "\n")[8], "23 24 25 26 27 28 29  28 29 30 31 32        24 25 26 27 28 29 30")
+}
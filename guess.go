@@ -0,0 +1,263 @@
+package bsdate
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Era tells if a date is written in the BS or the gregorian (AD) calendar
+type Era int
+
+const (
+	// EraUnknown is used in hints if the era is not known
+	EraUnknown Era = iota
+	// EraBS is the Bikram Sambat calendar
+	EraBS
+	// EraAD is the gregorian calendar
+	EraAD
+)
+
+func (e Era) String() string {
+	switch e {
+	case EraBS:
+		return "BS"
+	case EraAD:
+		return "AD"
+	}
+	return "unknown"
+}
+
+// ErrAmbiguousDate is returned by Guess if RejectAmbiguous is set and several interpretations are about as likely
+var ErrAmbiguousDate = errors.New("the date is ambiguous")
+
+// GuessOptions influence the scores of Guess
+type GuessOptions struct {
+	// Reference is a gregorian date the input is probably close to, e.g. today for dates of recent events.
+	// The zero time means there is no reference
+	Reference time.Time
+	// Era is the calendar the caller expects, e.g. because the form asks for a BS date
+	Era Era
+	// Orders are the field orders that are possible, all orders are possible if it is empty
+	Orders []DateOrder
+	// RejectAmbiguous makes Guess fail if the two best candidates are less than MinMargin apart
+	RejectAmbiguous bool
+	// MinMargin is the score difference RejectAmbiguous requires, 0 means DefaultGuessMargin
+	MinMargin float64
+}
+
+// DefaultGuessMargin is the score difference RejectAmbiguous requires by default
+const DefaultGuessMargin = 0.5
+
+// Candidate is one interpretation of the input of Guess
+type Candidate struct {
+	Era   Era
+	Order DateOrder
+	// Date is the date in BS, nil if a gregorian date is outside of the data of this module
+	Date Date
+	// Gregorian is the date in the gregorian calendar, the zero time if a BS date cannot be converted
+	Gregorian time.Time
+	// Score rates how likely the interpretation is, higher is better.
+	// Only the order and the difference of scores are meaningful
+	Score float64
+}
+
+// scores of the different reasons
+const (
+	scoreValid       = 1.0
+	scoreExplicitEra = 2.0
+	scoreHintedEra   = 1.0
+	scoreLocalDigits = 0.3
+	scoreReference   = 0.5
+	scoreOrderYMD    = 0.3
+	scoreOrderDMY    = 0.2
+	scoreOrderMDY    = 0.1
+	daysPerYear      = 365.25
+)
+
+// markers that name the era, longer ones first. BS and AD without dots are recognized as words
+var guessEraMarkers = []struct {
+	marker string
+	era    Era
+}{
+	{"वि.सं.", EraBS}, {"बि.सं.", EraBS}, {"वि. सं.", EraBS}, {"B.S.", EraBS}, {"B.S", EraBS},
+	{"ई.सं.", EraAD}, {"ई. सं.", EraAD}, {"A.D.", EraAD}, {"A.D", EraAD},
+}
+
+type guessField struct {
+	number, digits int
+	//month is set for month names, era tells to which calendar the name belongs
+	month int
+	era   Era
+}
+
+// Guess finds the possible interpretations of a date that might be written in BS or AD, like "2020-05-10"
+// (which is a valid date in both calendars). The candidates are sorted by their score, the best first.
+//
+// Only existing dates are candidates, so e.g. day 32 is only possible in BS and years outside the
+// data only in AD. The scores further depend on markers in the input like "B.S." or "A.D.",
+// BS or gregorian month names, Devanagari digits, the options and how common the order of the fields is
+func Guess(input string, options GuessOptions) ([]Candidate, error) {
+	var notADate = errors.New("\"" + input + "\" is not a date")
+	var text, markedEra = input, EraUnknown
+	for _, m := range guessEraMarkers {
+		if strings.Contains(text, m.marker) {
+			text = strings.Replace(text, m.marker, " ", 1)
+			markedEra = m.era
+			break
+		}
+	}
+
+	var fields []guessField
+	var localDigits = false
+	for _, token := range tokenize(text) {
+		switch {
+		case token.space || token.text == "/" || token.text == "-" || token.text == "." || token.text == ",":
+		case token.digits > 0:
+			fields = append(fields, guessField{number: token.number, digits: token.digits})
+			if first, _ := utf8.DecodeRuneInString(token.text); first > '9' {
+				localDigits = true
+			}
+		case strings.EqualFold(token.text, "BS") && markedEra == EraUnknown:
+			markedEra = EraBS
+		case strings.EqualFold(token.text, "AD") && markedEra == EraUnknown:
+			markedEra = EraAD
+		default:
+			if month, ok := ResolveMonthName(token.text); ok {
+				fields = append(fields, guessField{month: month, era: EraBS})
+			} else if month, ok := gregorianMonth(token.text); ok {
+				fields = append(fields, guessField{month: month, era: EraAD})
+			} else {
+				return nil, notADate
+			}
+		}
+	}
+	if len(fields) != 3 {
+		return nil, notADate
+	}
+
+	var candidates []Candidate
+	for _, order := range []DateOrder{YearMonthDay, DayMonthYear, MonthDayYear} {
+		if len(options.Orders) > 0 && !containsOrder(options.Orders, order) {
+			continue
+		}
+		year, month, day, monthEra, ok := orderFields(fields, order)
+		if !ok {
+			continue
+		}
+		for _, era := range []Era{EraBS, EraAD} {
+			if monthEra != EraUnknown && monthEra != era {
+				continue
+			}
+			candidate, ok := newCandidate(era, order, year, month, day)
+			if !ok {
+				continue
+			}
+			candidate.Score += orderScore(order)
+			if era == markedEra {
+				candidate.Score += scoreExplicitEra
+			}
+			if era == options.Era {
+				candidate.Score += scoreHintedEra
+			}
+			if era == EraBS && localDigits {
+				candidate.Score += scoreLocalDigits
+			}
+			if !options.Reference.IsZero() && !candidate.Gregorian.IsZero() {
+				var years = math.Abs(candidate.Gregorian.Sub(options.Reference).Hours()) / 24 / daysPerYear
+				candidate.Score += scoreReference / (1 + years)
+			}
+			candidates = append(candidates, candidate)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, notADate
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Score > candidates[j].Score })
+	if options.RejectAmbiguous && len(candidates) > 1 {
+		var margin = options.MinMargin
+		if margin == 0 {
+			margin = DefaultGuessMargin
+		}
+		if candidates[0].Score-candidates[1].Score < margin {
+			return candidates, ErrAmbiguousDate
+		}
+	}
+	return candidates, nil
+}
+
+// orderFields assigns the fields to year, month and day, the year needs four digits
+func orderFields(fields []guessField, order DateOrder) (year, month, day int, monthEra Era, ok bool) {
+	var y, m, d guessField
+	switch order {
+	case YearMonthDay:
+		y, m, d = fields[0], fields[1], fields[2]
+	case DayMonthYear:
+		d, m, y = fields[0], fields[1], fields[2]
+	case MonthDayYear:
+		m, d, y = fields[0], fields[1], fields[2]
+	}
+	if y.digits != 4 || d.digits == 0 || d.digits > 2 {
+		return 0, 0, 0, EraUnknown, false
+	}
+	if m.month > 0 {
+		return y.number, m.month, d.number, m.era, true
+	}
+	if m.digits == 0 || m.digits > 2 {
+		return 0, 0, 0, EraUnknown, false
+	}
+	return y.number, m.number, d.number, EraUnknown, true
+}
+
+// newCandidate returns the candidate if the date exists in the era
+func newCandidate(era Era, order DateOrder, year, month, day int) (Candidate, bool) {
+	var candidate = Candidate{Era: era, Order: order, Score: scoreValid}
+	if era == EraBS {
+		d, err := New(day, month, year)
+		if err != nil {
+			return candidate, false
+		}
+		candidate.Date = d
+		candidate.Gregorian, _ = d.GetGregorianDate()
+		return candidate, true
+	}
+	var gregorianDate = time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if gregorianDate.Year() != year || int(gregorianDate.Month()) != month || gregorianDate.Day() != day {
+		return candidate, false
+	}
+	candidate.Gregorian = gregorianDate
+	candidate.Date, _ = NewFromGregorian(day, month, year)
+	return candidate, true
+}
+
+func orderScore(order DateOrder) float64 {
+	switch order {
+	case YearMonthDay:
+		return scoreOrderYMD
+	case DayMonthYear:
+		return scoreOrderDMY
+	}
+	return scoreOrderMDY
+}
+
+func containsOrder(orders []DateOrder, order DateOrder) bool {
+	for _, o := range orders {
+		if o == order {
+			return true
+		}
+	}
+	return false
+}
+
+// gregorianMonth reads english month names like "May" or "Sep"
+func gregorianMonth(name string) (int, bool) {
+	for month := time.January; month <= time.December; month++ {
+		if strings.EqualFold(name, month.String()) || strings.EqualFold(name, month.String()[:3]) {
+			return int(month), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,96 @@
+package bsdate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+)
+
+// describe writes candidates like "BS YMD 2020-05-10" to compare them easily
+func describe(candidates []Candidate) []string {
+	var orders = map[DateOrder]string{YearMonthDay: "YMD", DayMonthYear: "DMY", MonthDayYear: "MDY"}
+	var result []string
+	for _, candidate := range candidates {
+		var date = candidate.Gregorian.Format("2006-01-02")
+		if candidate.Era == EraBS {
+			date = formatForTest(candidate.Date)
+		}
+		result = append(result, candidate.Era.String()+" "+orders[candidate.Order]+" "+date)
+	}
+	return result
+}
+
+func TestGuess(t *testing.T) {
+	var tests = []struct {
+		input    string
+		options  GuessOptions
+		expected []string
+	}{
+		{"2020-05-10", GuessOptions{}, []string{"BS YMD 2020-05-10", "AD YMD 2020-05-10"}},
+		{"2020-05-10", GuessOptions{Reference: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+			[]string{"AD YMD 2020-05-10", "BS YMD 2020-05-10"}},
+		{"2020-05-10", GuessOptions{Era: EraAD}, []string{"AD YMD 2020-05-10", "BS YMD 2020-05-10"}},
+		{"2020-05-10 B.S.", GuessOptions{}, []string{"BS YMD 2020-05-10", "AD YMD 2020-05-10"}},
+		{"AD 2020/05/10", GuessOptions{}, []string{"AD YMD 2020-05-10", "BS YMD 2020-05-10"}},
+		{"2081-03-32", GuessOptions{}, []string{"BS YMD 2081-03-32"}},
+		{"1950-06-15", GuessOptions{}, []string{"AD YMD 1950-06-15"}},
+		{"10/05/2020", GuessOptions{}, []string{
+			"BS DMY 2020-05-10", "AD DMY 2020-05-10", "BS MDY 2020-10-05", "AD MDY 2020-10-05",
+		}},
+		{"10/05/2020", GuessOptions{Orders: []DateOrder{MonthDayYear}}, []string{"BS MDY 2020-10-05", "AD MDY 2020-10-05"}},
+		{"२०८१-०३-१५", GuessOptions{}, []string{"BS YMD 2081-03-15", "AD YMD 2081-03-15"}},
+		{"15 Baisakh 2081", GuessOptions{}, []string{"BS DMY 2081-01-15"}},
+		{"15 Magh 2081", GuessOptions{}, []string{"BS DMY 2081-10-15"}},
+		{"May 15, 2020", GuessOptions{}, []string{"AD MDY 2020-05-15"}},
+		{"30/02/2020", GuessOptions{}, []string{"BS DMY 2020-02-30"}},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			candidates, err := Guess(test.input, test.options)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, describe(candidates), test.expected)
+		})
+	}
+}
+
+func TestGuessConvertsCandidates(t *testing.T) {
+	candidates, err := Guess("2081-03-32", GuessOptions{})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, candidates[0].Gregorian.Format("2006-01-02"), "2024-07-15")
+
+	candidates, err = Guess("2024-07-15 AD", GuessOptions{})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, formatForTest(candidates[0].Date), "2081-03-32")
+
+	//gregorian dates after the data of this module cannot be converted
+	candidates, err = Guess("2090-01-01", GuessOptions{Era: EraAD})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, candidates[0].Era, EraAD)
+	assert.Equal(t, candidates[0].Date, nil)
+}
+
+func TestGuessRejectAmbiguous(t *testing.T) {
+	var options = GuessOptions{RejectAmbiguous: true}
+	candidates, err := Guess("2020-05-10", options)
+	assert.Equal(t, err, ErrAmbiguousDate)
+	assert.Equal(t, len(candidates), 2)
+
+	_, err = Guess("2020-05-10 BS", options)
+	assert.Equal(t, err, nil)
+	_, err = Guess("२०८१-०३-१५", options)
+	assert.Equal(t, err, ErrAmbiguousDate)
+	_, err = Guess("२०८१-०३-१५", GuessOptions{RejectAmbiguous: true, MinMargin: 0.2})
+	assert.Equal(t, err, nil)
+	_, err = Guess("2081-03-32", options)
+	assert.Equal(t, err, nil)
+}
+
+func TestGuessInvalid(t *testing.T) {
+	for _, input := range []string{"", "hello", "2020-05", "2020-13-40", "20-05-10", "10 Foo 2020", "2020-05-10-01"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := Guess(input, GuessOptions{})
+			assert.Equal(t, err.Error(), "\""+input+"\" is not a date")
+		})
+	}
+}
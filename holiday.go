@@ -0,0 +1,197 @@
+package bsdate
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sort"
+)
+
+// HolidayCategory groups holidays by their reason
+type HolidayCategory string
+
+const (
+	NationalHoliday HolidayCategory = "national"
+	CulturalHoliday HolidayCategory = "cultural"
+	MemorialHoliday HolidayCategory = "memorial"
+)
+
+// Applicability describes who gets a day off on a holiday
+type Applicability string
+
+const (
+	ApplicableToAll Applicability = "all"
+	GovernmentOnly  Applicability = "government"
+)
+
+// Holiday is a holiday that is celebrated every year on the same BS month and day
+type Holiday struct {
+	Name          string          `json:"name"`
+	NameNepali    string          `json:"name_ne"`
+	Month         int             `json:"month"`
+	Day           int             `json:"day"`
+	Category      HolidayCategory `json:"category"`
+	Applicability Applicability   `json:"applicability"`
+	// FromYear is the first BS year the holiday is observed in, 0 if there is no such limit
+	FromYear int `json:"from_year,omitempty"`
+	// ToYear is the last BS year the holiday is observed in, 0 if there is no such limit
+	ToYear int `json:"to_year,omitempty"`
+}
+
+// ObservedIn reports if the holiday exists in the given BS year
+func (h Holiday) ObservedIn(year int) bool {
+	return (h.FromYear == 0 || year >= h.FromYear) && (h.ToYear == 0 || year <= h.ToYear)
+}
+
+func (h Holiday) validate() error {
+	if h.Name == "" {
+		return errors.New("holiday needs a name")
+	}
+	if h.Month <= 0 || h.Month > 12 || h.Day <= 0 || h.Day > 32 {
+		return errors.New("holiday " + h.Name + " has an invalid month or day")
+	}
+	if h.FromYear != 0 && h.ToYear != 0 && h.FromYear > h.ToYear {
+		return errors.New("holiday " + h.Name + " ends before it starts")
+	}
+	return nil
+}
+
+// HolidayDate is a holiday together with the date it falls on in a specific year
+type HolidayDate struct {
+	Holiday
+	Date Date
+}
+
+// HolidayRegistry is a list of holidays that are keyed on BS month and day
+type HolidayRegistry struct {
+	holidays []Holiday
+}
+
+// NationalHolidays are the public holidays of Nepal that fall on a fixed BS date
+var NationalHolidays = []Holiday{
+	{Name: "Nepali New Year", NameNepali: "नयाँ वर्ष", Month: 1, Day: 1, Category: NationalHoliday, Applicability: ApplicableToAll},
+	{Name: "Loktantra Diwas", NameNepali: "लोकतन्त्र दिवस", Month: 1, Day: 11, Category: NationalHoliday, Applicability: ApplicableToAll, FromYear: 2063},
+	{Name: "Republic Day", NameNepali: "गणतन्त्र दिवस", Month: 2, Day: 15, Category: NationalHoliday, Applicability: ApplicableToAll, FromYear: 2065},
+	{Name: "Civil Service Day", NameNepali: "निजामती सेवा दिवस", Month: 5, Day: 22, Category: NationalHoliday, Applicability: GovernmentOnly, FromYear: 2062},
+	{Name: "Constitution Day", NameNepali: "संविधान दिवस", Month: 6, Day: 3, Category: NationalHoliday, Applicability: ApplicableToAll, FromYear: 2072},
+	{Name: "Prithvi Jayanti", NameNepali: "पृथ्वी जयन्ती", Month: 9, Day: 27, Category: NationalHoliday, Applicability: ApplicableToAll, FromYear: 2078},
+	{Name: "Maghe Sankranti", NameNepali: "माघे सङ्क्रान्ति", Month: 10, Day: 1, Category: CulturalHoliday, Applicability: ApplicableToAll},
+	{Name: "Martyrs' Day", NameNepali: "शहीद दिवस", Month: 10, Day: 16, Category: MemorialHoliday, Applicability: ApplicableToAll},
+	{Name: "Democracy Day", NameNepali: "प्रजातन्त्र दिवस", Month: 11, Day: 7, Category: NationalHoliday, Applicability: ApplicableToAll, FromYear: 2008},
+}
+
+// NewHolidayRegistry creates a registry containing the given holidays
+func NewHolidayRegistry(holidays ...Holiday) (*HolidayRegistry, error) {
+	var r = &HolidayRegistry{}
+	if err := r.Add(holidays...); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// DefaultHolidayRegistry creates a registry containing the NationalHolidays
+func DefaultHolidayRegistry() *HolidayRegistry {
+	var r = &HolidayRegistry{}
+	r.holidays = append(r.holidays, NationalHolidays...)
+	return r
+}
+
+// Add adds holidays to the registry, if any of them is invalid none is added
+func (r *HolidayRegistry) Add(holidays ...Holiday) error {
+	for _, h := range holidays {
+		if err := h.validate(); err != nil {
+			return err
+		}
+	}
+	r.holidays = append(r.holidays, holidays...)
+	return nil
+}
+
+// Holidays returns all holidays of the registry
+func (r *HolidayRegistry) Holidays() []Holiday {
+	return append([]Holiday(nil), r.holidays...)
+}
+
+// Filter returns a new registry with only the holidays for which keep returns true,
+// e.g. to leave out holidays that are only for government offices
+func (r *HolidayRegistry) Filter(keep func(Holiday) bool) *HolidayRegistry {
+	var filtered = &HolidayRegistry{}
+	for _, h := range r.holidays {
+		if keep(h) {
+			filtered.holidays = append(filtered.holidays, h)
+		}
+	}
+	return filtered
+}
+
+// HolidaysOn returns all holidays that fall on d
+func (r *HolidayRegistry) HolidaysOn(d Date) []Holiday {
+	var result []Holiday
+	for _, h := range r.holidays {
+		if h.Month == d.GetMonth() && h.Day == d.GetDay() && h.ObservedIn(d.GetYear()) {
+			result = append(result, h)
+		}
+	}
+	return result
+}
+
+func (r *HolidayRegistry) IsHoliday(d Date) bool {
+	return len(r.HolidaysOn(d)) > 0
+}
+
+// HolidaysIn returns the holidays of a month sorted by day.
+// Holidays on a day the month does not have in that year (e.g. the 32nd) are left out
+func (r *HolidayRegistry) HolidaysIn(ym YearMonth) []HolidayDate {
+	var result []HolidayDate
+	for _, h := range r.holidays {
+		if h.Month != ym.Month || !h.ObservedIn(ym.Year) {
+			continue
+		}
+		d, err := New(h.Day, h.Month, ym.Year)
+		if err != nil {
+			continue
+		}
+		result = append(result, HolidayDate{Holiday: h, Date: d})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Day < result[j].Day
+	})
+	return result
+}
+
+// HolidaysInYear returns the holidays of a whole BS year sorted by date
+func (r *HolidayRegistry) HolidaysInYear(year int) []HolidayDate {
+	var result []HolidayDate
+	for month := 1; month <= 12; month++ {
+		result = append(result, r.HolidaysIn(YearMonth{Year: year, Month: month})...)
+	}
+	return result
+}
+
+// LoadHolidays reads a JSON array of holidays, e.g.
+//
+//	[{"name": "Republic Day", "name_ne": "गणतन्त्र दिवस", "month": 2, "day": 15,
+//	  "category": "national", "applicability": "all", "from_year": 2065}]
+func LoadHolidays(reader io.Reader) ([]Holiday, error) {
+	var holidays []Holiday
+	if err := json.NewDecoder(reader).Decode(&holidays); err != nil {
+		return nil, err
+	}
+	for _, h := range holidays {
+		if err := h.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return holidays, nil
+}
+
+// LoadHolidaysFile reads a JSON file in the format of LoadHolidays
+func LoadHolidaysFile(path string) ([]Holiday, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return LoadHolidays(file)
+}
@@ -0,0 +1,97 @@
+package bsdate
+
+import (
+	"github.com/magiconair/properties/assert"
+	"strings"
+	"testing"
+)
+
+func TestHolidaysOn(t *testing.T) {
+	var r = DefaultHolidayRegistry()
+	var holidays = r.HolidaysOn(mustNew(t, "2081-02-15"))
+	assert.Equal(t, len(holidays), 1)
+	assert.Equal(t, holidays[0].Name, "Republic Day")
+	assert.Equal(t, holidays[0].NameNepali, "गणतन्त्र दिवस")
+	assert.Equal(t, r.IsHoliday(mustNew(t, "2081-01-01")), true)
+	assert.Equal(t, r.IsHoliday(mustNew(t, "2081-01-02")), false)
+	assert.Equal(t, r.IsHoliday(mustNew(t, "2060-02-15")), false) //before Nepal became a republic
+	assert.Equal(t, r.IsHoliday(mustNew(t, "2072-06-03")), true)
+	assert.Equal(t, r.IsHoliday(mustNew(t, "2071-06-03")), false)
+}
+
+func TestHolidaysIn(t *testing.T) {
+	var r = DefaultHolidayRegistry()
+	var holidays = r.HolidaysIn(YearMonth{Year: 2081, Month: 10})
+	assert.Equal(t, len(holidays), 2)
+	assert.Equal(t, holidays[0].Name, "Maghe Sankranti")
+	assert.Equal(t, formatForTest(holidays[0].Date), "2081-10-01")
+	assert.Equal(t, holidays[1].Name, "Martyrs' Day")
+	assert.Equal(t, formatForTest(holidays[1].Date), "2081-10-16")
+
+	assert.Equal(t, len(r.HolidaysIn(YearMonth{Year: 2081, Month: 3})), 0)
+	assert.Equal(t, len(r.HolidaysInYear(2081)), len(NationalHolidays))
+}
+
+func TestHolidayOnNotExistingDay(t *testing.T) {
+	r, err := NewHolidayRegistry(Holiday{Name: "End of Ashadh", Month: 3, Day: 32})
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(r.HolidaysIn(YearMonth{Year: 2081, Month: 3})), 1)
+	assert.Equal(t, len(r.HolidaysIn(YearMonth{Year: 2080, Month: 3})), 0) //Ashadh 2080 has 31 days
+}
+
+func TestInvalidHolidays(t *testing.T) {
+	_, err := NewHolidayRegistry(Holiday{Month: 3, Day: 1})
+	assert.Equal(t, err.Error(), "holiday needs a name")
+	_, err = NewHolidayRegistry(Holiday{Name: "x", Month: 13, Day: 1})
+	assert.Equal(t, err.Error(), "holiday x has an invalid month or day")
+	_, err = NewHolidayRegistry(Holiday{Name: "x", Month: 1, Day: 33})
+	assert.Equal(t, err.Error(), "holiday x has an invalid month or day")
+	_, err = NewHolidayRegistry(Holiday{Name: "x", Month: 1, Day: 1, FromYear: 2080, ToYear: 2079})
+	assert.Equal(t, err.Error(), "holiday x ends before it starts")
+}
+
+func TestFilterHolidays(t *testing.T) {
+	var r = DefaultHolidayRegistry()
+	var civilServiceDay = mustNew(t, "2081-05-22")
+	assert.Equal(t, r.IsHoliday(civilServiceDay), true)
+	var banks = r.Filter(func(h Holiday) bool {
+		return h.Applicability != GovernmentOnly
+	})
+	assert.Equal(t, banks.IsHoliday(civilServiceDay), false)
+	assert.Equal(t, banks.IsHoliday(mustNew(t, "2081-01-01")), true)
+}
+
+func TestLoadHolidays(t *testing.T) {
+	var input = `[
+		{"name": "Company Day", "name_ne": "कम्पनी दिवस", "month": 4, "day": 10, "category": "cultural", "applicability": "all", "from_year": 2075},
+		{"name": "Old Holiday", "month": 5, "day": 1, "to_year": 2070}
+	]`
+	holidays, err := LoadHolidays(strings.NewReader(input))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, holidays[0], Holiday{
+		Name: "Company Day", NameNepali: "कम्पनी दिवस", Month: 4, Day: 10,
+		Category: CulturalHoliday, Applicability: ApplicableToAll, FromYear: 2075,
+	})
+	r, err := NewHolidayRegistry(holidays...)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, r.IsHoliday(mustNew(t, "2081-04-10")), true)
+	assert.Equal(t, r.IsHoliday(mustNew(t, "2070-05-01")), true)
+	assert.Equal(t, r.IsHoliday(mustNew(t, "2071-05-01")), false)
+
+	_, err = LoadHolidays(strings.NewReader(`[{"name": "x", "month": 0, "day": 1}]`))
+	assert.Equal(t, err.Error(), "holiday x has an invalid month or day")
+	_, err = LoadHolidays(strings.NewReader(`{`))
+	assert.Equal(t, err != nil, true)
+	_, err = LoadHolidaysFile("not-existing.json")
+	assert.Equal(t, err != nil, true)
+}
+
+func TestBusinessCalendarWithHolidayRegistry(t *testing.T) {
+	var c = NewBusinessCalendar()
+	c.AddHolidayRegistry(DefaultHolidayRegistry())
+	var newYear = mustNew(t, "2082-01-01") //a Monday
+	assert.Equal(t, c.IsBusinessDay(newYear), false)
+	next, err := c.NextBusinessDay(mustNew(t, "2081-12-30"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, formatForTest(next), "2082-01-02")
+}
@@ -0,0 +1,379 @@
+// Package ical exports BS dated events to iCalendar (RFC 5545) and imports them back.
+//
+// Calendar applications only know gregorian dates, so all dates are converted with GetGregorianDate.
+// The BS dates are kept in X-BS-* properties and can optionally be added to the summary.
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"strings"
+	"time"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+)
+
+const timeZoneID = "Asia/Kathmandu"
+
+// Event is a BS dated calendar entry.
+// Events without Duration are all-day events, else they start at StartTime on every day they occur on
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       bsdate.Date
+	// End is the last day of an all-day event spanning multiple days, nil for single days
+	End bsdate.Date
+	// StartTime is the time of the day in Nepal the event starts, only used if Duration is set
+	StartTime time.Duration
+	Duration  time.Duration
+	// Recurrences are further days the event takes place on, e.g. the dates a festival falls on in the next years
+	Recurrences []bsdate.Date
+}
+
+// HolidayEvents turns resolved holidays into all-day events, e.g. from HolidayRegistry.HolidaysInYear
+func HolidayEvents(holidays []bsdate.HolidayDate) []Event {
+	var events = make([]Event, 0, len(holidays))
+	for _, holiday := range holidays {
+		events = append(events, Event{
+			UID:         fmt.Sprintf("%s-%s@bsdate", formatBS(holiday.Date), strings.ToLower(strings.Replace(holiday.Name, " ", "-", -1))),
+			Summary:     holiday.Name,
+			Description: holiday.NameNepali,
+			Start:       holiday.Date,
+		})
+	}
+	return events
+}
+
+// IsAllDay reports if the event takes whole days
+func (e Event) IsAllDay() bool {
+	return e.Duration == 0
+}
+
+// Encoder writes events as an iCalendar stream
+type Encoder struct {
+	w *bufio.Writer
+	// ProductID is written as PRODID of the calendar
+	ProductID string
+	// Name is written as X-WR-CALNAME, the name most calendar applications show
+	Name string
+	// BSDateInSummary appends the BS start date to the summary, e.g. "Republic Day (2081-02-15 BS)"
+	BSDateInSummary bool
+	// Stamp is used as DTSTAMP of the events, defaults to the current time
+	Stamp time.Time
+}
+
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: bufio.NewWriter(w), ProductID: "-//JankariTech//GoBikramSambat//EN"}
+}
+
+// Encode writes a VCALENDAR containing the given events
+func (e *Encoder) Encode(events []Event) error {
+	var stamp = e.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+	e.writeLine("BEGIN:VCALENDAR")
+	e.writeLine("VERSION:2.0")
+	e.writeLine("PRODID:" + e.ProductID)
+	e.writeLine("CALSCALE:GREGORIAN")
+	if e.Name != "" {
+		e.writeLine("X-WR-CALNAME:" + escapeText(e.Name))
+	}
+	e.writeLine("BEGIN:VTIMEZONE")
+	e.writeLine("TZID:" + timeZoneID)
+	e.writeLine("BEGIN:STANDARD")
+	e.writeLine("DTSTART:19860101T000000")
+	e.writeLine("TZOFFSETFROM:+0530")
+	e.writeLine("TZOFFSETTO:+0545")
+	e.writeLine("TZNAME:+0545")
+	e.writeLine("END:STANDARD")
+	e.writeLine("END:VTIMEZONE")
+	for _, event := range events {
+		if err := e.encodeEvent(event, stamp); err != nil {
+			return err
+		}
+	}
+	e.writeLine("END:VCALENDAR")
+	return e.w.Flush()
+}
+
+func (e *Encoder) encodeEvent(event Event, stamp time.Time) error {
+	if event.Start == nil {
+		return errors.New("event " + event.Summary + " has no start date")
+	}
+	start, err := event.Start.GetGregorianDate()
+	if err != nil {
+		return err
+	}
+	var uid = event.UID
+	if uid == "" {
+		var hash = fnv.New32a()
+		_, _ = hash.Write([]byte(event.Summary))
+		uid = fmt.Sprintf("%s-%08x@bsdate", formatBS(event.Start), hash.Sum32())
+	}
+	var summary = event.Summary
+	if e.BSDateInSummary {
+		summary += " (" + formatBS(event.Start) + " BS)"
+	}
+
+	e.writeLine("BEGIN:VEVENT")
+	e.writeLine("UID:" + uid)
+	e.writeLine("DTSTAMP:" + stamp.UTC().Format("20060102T150405Z"))
+	e.writeLine("SUMMARY:" + escapeText(summary))
+	if event.Description != "" {
+		e.writeLine("DESCRIPTION:" + escapeText(event.Description))
+	}
+	if event.IsAllDay() {
+		var afterEnd = start.AddDate(0, 0, 1)
+		if event.End != nil {
+			end, err := event.End.GetGregorianDate()
+			if err != nil {
+				return err
+			}
+			if end.Before(start) {
+				return errors.New("event " + event.Summary + " ends before it starts")
+			}
+			afterEnd = end.AddDate(0, 0, 1)
+		}
+		e.writeLine("DTSTART;VALUE=DATE:" + start.Format("20060102"))
+		e.writeLine("DTEND;VALUE=DATE:" + afterEnd.Format("20060102"))
+	} else {
+		var startTime = localTime(start, event.StartTime)
+		e.writeLine("DTSTART;TZID=" + timeZoneID + ":" + startTime.Format("20060102T150405"))
+		e.writeLine("DTEND;TZID=" + timeZoneID + ":" + startTime.Add(event.Duration).Format("20060102T150405"))
+	}
+	e.writeLine("X-BS-DTSTART:" + formatBS(event.Start))
+	if event.End != nil && event.IsAllDay() {
+		e.writeLine("X-BS-DTEND:" + formatBS(event.End))
+	}
+	if len(event.Recurrences) > 0 {
+		var gregorianDates, bsDates []string
+		for _, recurrence := range event.Recurrences {
+			gregorianDate, err := recurrence.GetGregorianDate()
+			if err != nil {
+				return err
+			}
+			if event.IsAllDay() {
+				gregorianDates = append(gregorianDates, gregorianDate.Format("20060102"))
+			} else {
+				gregorianDates = append(gregorianDates, localTime(gregorianDate, event.StartTime).Format("20060102T150405"))
+			}
+			bsDates = append(bsDates, formatBS(recurrence))
+		}
+		if event.IsAllDay() {
+			e.writeLine("RDATE;VALUE=DATE:" + strings.Join(gregorianDates, ","))
+		} else {
+			e.writeLine("RDATE;TZID=" + timeZoneID + ":" + strings.Join(gregorianDates, ","))
+		}
+		e.writeLine("X-BS-RDATE:" + strings.Join(bsDates, ","))
+	}
+	e.writeLine("END:VEVENT")
+	return nil
+}
+
+// writeLine writes a content line folded after 75 octets, without splitting UTF-8 characters
+func (e *Encoder) writeLine(line string) {
+	var octets = 0
+	for _, character := range line {
+		var size = len(string(character))
+		if octets+size > 75 {
+			_, _ = e.w.WriteString("\r\n ")
+			octets = 1
+		}
+		_, _ = e.w.WriteRune(character)
+		octets += size
+	}
+	_, _ = e.w.WriteString("\r\n")
+}
+
+// Decode reads all VEVENTs of an iCalendar stream and converts their dates to BS
+func Decode(r io.Reader) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+	var events []Event
+	var current *Event
+	var gregorianStart, gregorianEnd time.Time
+	var startIsDate bool
+	for _, line := range lines {
+		name, params, value, err := splitLine(line)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			current = &Event{}
+			gregorianStart, gregorianEnd = time.Time{}, time.Time{}
+		case name == "END" && value == "VEVENT":
+			if current == nil {
+				return nil, errors.New("END:VEVENT without BEGIN:VEVENT")
+			}
+			if err := current.setTimes(gregorianStart, gregorianEnd, startIsDate); err != nil {
+				return nil, err
+			}
+			events = append(events, *current)
+			current = nil
+		case current == nil:
+			continue
+		case name == "UID":
+			current.UID = value
+		case name == "SUMMARY":
+			current.Summary = unescapeText(value)
+		case name == "DESCRIPTION":
+			current.Description = unescapeText(value)
+		case name == "DTSTART":
+			gregorianStart, startIsDate, err = parseDateTime(value, params)
+			if err != nil {
+				return nil, err
+			}
+		case name == "DTEND":
+			gregorianEnd, _, err = parseDateTime(value, params)
+			if err != nil {
+				return nil, err
+			}
+		case name == "RDATE":
+			for _, rdate := range strings.Split(value, ",") {
+				gregorianDate, _, err := parseDateTime(rdate, params)
+				if err != nil {
+					return nil, err
+				}
+				recurrence, err := toBS(gregorianDate)
+				if err != nil {
+					return nil, err
+				}
+				current.Recurrences = append(current.Recurrences, recurrence)
+			}
+		}
+	}
+	if current != nil {
+		return nil, errors.New("VEVENT is not closed")
+	}
+	return events, nil
+}
+
+// setTimes converts the gregorian start and end of the event to BS dates,
+// times are converted to Nepal time first
+func (e *Event) setTimes(start, end time.Time, allDay bool) error {
+	if start.IsZero() {
+		return errors.New("event " + e.Summary + " has no DTSTART")
+	}
+	var err error
+	e.Start, err = toBS(start)
+	if err != nil {
+		return err
+	}
+	if allDay {
+		//DTEND of all-day events is the first day after the event
+		if !end.IsZero() && end.After(start.AddDate(0, 0, 1)) {
+			e.End, err = toBS(end.AddDate(0, 0, -1))
+		}
+		return err
+	}
+	var local = start.In(bsdate.NepalTime)
+	e.StartTime = time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute +
+		time.Duration(local.Second())*time.Second
+	e.Duration = time.Hour
+	if !end.IsZero() {
+		e.Duration = end.Sub(start)
+	}
+	return nil
+}
+
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	var scanner = bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var line = strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+// splitLine splits a content line like DTSTART;TZID=Asia/Kathmandu:20240413T100000 into its parts
+func splitLine(line string) (name string, params map[string]string, value string, err error) {
+	var colon = -1
+	var quoted = false
+	for i, character := range line {
+		if character == '"' {
+			quoted = !quoted
+		}
+		if character == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return "", nil, "", errors.New("invalid content line: " + line)
+	}
+	var parts = strings.Split(line[:colon], ";")
+	params = map[string]string{}
+	for _, param := range parts[1:] {
+		var keyValue = strings.SplitN(param, "=", 2)
+		if len(keyValue) == 2 {
+			params[strings.ToUpper(keyValue[0])] = strings.Trim(keyValue[1], "\"")
+		}
+	}
+	return strings.ToUpper(parts[0]), params, line[colon+1:], nil
+}
+
+// parseDateTime reads DATE and DATE-TIME values, the second return value is true for DATE values
+func parseDateTime(value string, params map[string]string) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == 8 {
+		t, err := time.Parse("20060102", value)
+		return t, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		return t, false, err
+	}
+	var location = bsdate.NepalTime
+	if tzid, ok := params["TZID"]; ok && tzid != timeZoneID {
+		var err error
+		location, err = time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, location)
+	return t, false, err
+}
+
+// toBS converts the day of t to a BS date, times are looked at in Nepal time, dates as they are
+func toBS(t time.Time) (bsdate.Date, error) {
+	if t.Location() != time.UTC || t.Hour() != 0 || t.Minute() != 0 || t.Second() != 0 {
+		t = t.In(bsdate.NepalTime)
+	}
+	return bsdate.NewFromGregorian(t.Day(), int(t.Month()), t.Year())
+}
+
+func localTime(gregorianDate time.Time, timeOfDay time.Duration) time.Time {
+	return time.Date(gregorianDate.Year(), gregorianDate.Month(), gregorianDate.Day(), 0, 0, 0, 0, bsdate.NepalTime).
+		Add(timeOfDay)
+}
+
+func formatBS(d bsdate.Date) string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.GetYear(), d.GetMonth(), d.GetDay())
+}
+
+var textEscaper = strings.NewReplacer("\\", "\\\\", ";", "\\;", ",", "\\,", "\n", "\\n")
+var textUnescaper = strings.NewReplacer("\\\\", "\\", "\\;", ";", "\\,", ",", "\\n", "\n", "\\N", "\n")
+
+func escapeText(text string) string {
+	return textEscaper.Replace(text)
+}
+
+func unescapeText(text string) string {
+	return textUnescaper.Replace(text)
+}
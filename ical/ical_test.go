@@ -0,0 +1,132 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+	"github.com/magiconair/properties/assert"
+)
+
+func mustNew(t *testing.T, day, month, year int) bsdate.Date {
+	d, err := bsdate.New(day, month, year)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func encode(t *testing.T, encoder func(*Encoder), events ...Event) string {
+	var buffer bytes.Buffer
+	var e = NewEncoder(&buffer)
+	e.Stamp = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if encoder != nil {
+		encoder(e)
+	}
+	if err := e.Encode(events); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.String()
+}
+
+func TestEncodeAllDayEvent(t *testing.T) {
+	var output = encode(t, func(e *Encoder) { e.BSDateInSummary = true }, Event{
+		UID:     "republic-day",
+		Summary: "Republic Day",
+		Start:   mustNew(t, 15, 2, 2081),
+	})
+	assert.Equal(t, strings.HasPrefix(output, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"), true)
+	assert.Equal(t, strings.HasSuffix(output, "END:VEVENT\r\nEND:VCALENDAR\r\n"), true)
+	assert.Equal(t, strings.Contains(output, "\r\nTZID:Asia/Kathmandu\r\n"), true)
+	assert.Equal(t, strings.Contains(output, "\r\nSUMMARY:Republic Day (2081-02-15 BS)\r\n"), true)
+	assert.Equal(t, strings.Contains(output, "\r\nDTSTART;VALUE=DATE:20240528\r\n"), true)
+	assert.Equal(t, strings.Contains(output, "\r\nDTEND;VALUE=DATE:20240529\r\n"), true)
+	assert.Equal(t, strings.Contains(output, "\r\nX-BS-DTSTART:2081-02-15\r\n"), true)
+	assert.Equal(t, strings.Contains(output, "\r\nDTSTAMP:20240101T000000Z\r\n"), true)
+}
+
+func TestEncodeTimedEvent(t *testing.T) {
+	var output = encode(t, nil, Event{
+		Summary:     "Meeting",
+		Start:       mustNew(t, 1, 1, 2081),
+		StartTime:   10*time.Hour + 30*time.Minute,
+		Duration:    90 * time.Minute,
+		Recurrences: []bsdate.Date{mustNew(t, 1, 1, 2082)},
+	})
+	assert.Equal(t, strings.Contains(output, "\r\nDTSTART;TZID=Asia/Kathmandu:20240413T103000\r\n"), true)
+	assert.Equal(t, strings.Contains(output, "\r\nDTEND;TZID=Asia/Kathmandu:20240413T120000\r\n"), true)
+	assert.Equal(t, strings.Contains(output, "\r\nRDATE;TZID=Asia/Kathmandu:20250414T103000\r\n"), true)
+	assert.Equal(t, strings.Contains(output, "\r\nX-BS-RDATE:2082-01-01\r\n"), true)
+	assert.Equal(t, strings.Contains(output, "\r\nUID:2081-01-01-"), true)
+}
+
+func TestEncodeFoldsAndEscapes(t *testing.T) {
+	var output = encode(t, nil, Event{
+		Summary:     "Dashain; Tihar, Chhath",
+		Description: strings.Repeat("विजया दशमीको शुभकामना ", 5),
+		Start:       mustNew(t, 26, 6, 2081),
+	})
+	assert.Equal(t, strings.Contains(output, "\r\nSUMMARY:Dashain\\; Tihar\\, Chhath\r\n"), true)
+	for _, line := range strings.Split(output, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line is longer than 75 octets: %q", line)
+		}
+	}
+	events, err := Decode(strings.NewReader(output))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, events[0].Summary, "Dashain; Tihar, Chhath")
+	assert.Equal(t, events[0].Description, strings.Repeat("विजया दशमीको शुभकामना ", 5))
+}
+
+func TestRoundTrip(t *testing.T) {
+	var events = []Event{
+		{UID: "a", Summary: "Dashain", Start: mustNew(t, 26, 6, 2081), End: mustNew(t, 30, 6, 2081)},
+		{UID: "b", Summary: "Meeting", Start: mustNew(t, 32, 3, 2081), StartTime: 23 * time.Hour, Duration: 2 * time.Hour},
+	}
+	decoded, err := Decode(strings.NewReader(encode(t, nil, events...)))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(decoded), 2)
+	for i, event := range decoded {
+		assert.Equal(t, event.UID, events[i].UID)
+		assert.Equal(t, event.Start, events[i].Start)
+		assert.Equal(t, event.End, events[i].End)
+		assert.Equal(t, event.StartTime, events[i].StartTime)
+		assert.Equal(t, event.Duration, events[i].Duration)
+	}
+}
+
+func TestDecodeUTC(t *testing.T) {
+	var input = "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:Late call\r\n" +
+		"DTSTART:20240413T190000Z\r\nDTEND:20240413T200000Z\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+	events, err := Decode(strings.NewReader(input))
+	assert.Equal(t, err, nil)
+	//19:00 UTC is already the next day in Nepal
+	assert.Equal(t, events[0].Start, mustNew(t, 2, 1, 2081))
+	assert.Equal(t, events[0].StartTime, 45*time.Minute)
+	assert.Equal(t, events[0].Duration, time.Hour)
+}
+
+func TestDecodeErrors(t *testing.T) {
+	var tests = []string{
+		"BEGIN:VEVENT\r\nSUMMARY:no start\r\nEND:VEVENT\r\n",
+		"BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20240413\r\n",
+		"BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:2024-04-13\r\nEND:VEVENT\r\n",
+		"BEGIN:VEVENT\r\nno colon\r\nEND:VEVENT\r\n",
+	}
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			_, err := Decode(strings.NewReader(test))
+			assert.Equal(t, err != nil, true)
+		})
+	}
+}
+
+func TestHolidayEvents(t *testing.T) {
+	var events = HolidayEvents(bsdate.DefaultHolidayRegistry().HolidaysIn(bsdate.YearMonth{Year: 2081, Month: 2}))
+	assert.Equal(t, len(events), 1)
+	assert.Equal(t, events[0].UID, "2081-02-15-republic-day@bsdate")
+	assert.Equal(t, events[0].Description, "गणतन्त्र दिवस")
+	assert.Equal(t, strings.Contains(encode(t, nil, events...), "\r\nDTSTART;VALUE=DATE:20240528\r\n"), true)
+}
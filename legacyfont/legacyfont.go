@@ -0,0 +1,188 @@
+// Package legacyfont converts Nepali text typed with legacy ASCII fonts like Preeti to Unicode.
+//
+// Before Unicode was common, Nepali documents were written with fonts that draw Devanagari glyphs
+// for ASCII characters, so a date that reads २०६८ साल बैशाख १५ गते is stored as "@)^* ;fn a}zfv !% ut]".
+// ToUnicode turns such text into Unicode and ParseDate reads a BS date from it.
+//
+// Only the layout of Preeti is built in. Kantipur is a Preeti compatible font and shares its layout, but fonts with
+// layouts of their own, like Sagarmatha or Himali, are not supported yet: text written with them is converted to
+// wrong letters. Such fonts can be used by filling an Encoding with their mapping.
+package legacyfont
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+)
+
+// Encoding describes how a legacy font maps characters to Devanagari
+type Encoding struct {
+	Name string
+	// Sequences are replaced before the single characters, they are tried longest first
+	Sequences map[string]string
+	// Characters maps single characters, characters that are not in it are kept as they are
+	Characters map[rune]string
+}
+
+// reph marks the "र्" of '{', it is moved in front of its consonant after the mapping
+const reph = '\uE000'
+
+// Preeti is the most common legacy Nepali font
+var Preeti = &Encoding{
+	Name: "Preeti",
+	Sequences: map[string]string{
+		"cf]": "ओ", "cf}": "औ", "cf": "आ", "O{": "ई", "P]": "ऐ", "pm": "ऊ",
+	},
+	Characters: map[rune]string{
+		'a': "ब", 'b': "द", 'c': "अ", 'd': "म", 'e': "भ", 'f': "ा", 'g': "न", 'h': "ज", 'i': "ष्", 'j': "व",
+		'k': "प", 'l': "ि", 'm': "ः", 'n': "ल", 'o': "य", 'p': "उ", 'q': "त्र", 'r': "च", 's': "क", 't': "त",
+		'u': "ग", 'v': "ख", 'w': "ध", 'x': "ह", 'y': "थ", 'z': "श",
+		'A': "ब्", 'B': "द्य", 'C': "ऋ", 'D': "म्", 'E': "भ्", 'F': "ँ", 'G': "न्", 'H': "ज्", 'I': "क्ष्", 'J': "व्",
+		'K': "प्", 'L': "ी", 'M': "ः", 'N': "ल्", 'O': "इ", 'P': "ए", 'Q': "त्त", 'R': "च्", 'S': "क्", 'T': "त्",
+		'U': "ग्", 'V': "ख्", 'W': "ध्", 'X': "ह्", 'Y': "थ्", 'Z': "श्",
+		'0': "ण्", '1': "ज्ञ", '2': "द्द", '3': "घ", '4': "ध्", '5': "छ", '6': "ट", '7': "ठ", '8': "ड", '9': "ढ",
+		')': "०", '!': "१", '@': "२", '#': "३", '$': "४", '%': "५", '^': "६", '&': "७", '*': "८", '(': "९",
+		';': "स", ':': "स्", '\'': "ु", '"': "ू", '[': "ृ", '{': string(reph), ']': "े", '}': "ै", '\\': "्",
+		'|': "्र", '/': "र", '?': "रु", '.': "।", '=': ".", '+': "ं", '_': ")", '-': "(", '<': "?", '>': "श्र",
+		'`': "ञ्", '~': "ञ्",
+		'÷': "/", '¿': "रू", 'å': "द्व", 'Ø': "्य", 'ç': "ॐ", '•': "ड्ड", 'ˆ': "फ्",
+	},
+}
+
+// Kantipur is a Preeti compatible font, it is Preeti under its own name so callers can say which font a text uses
+var Kantipur = &Encoding{
+	Name:       "Kantipur",
+	Sequences:  Preeti.Sequences,
+	Characters: Preeti.Characters,
+}
+
+// ToUnicode converts text written with the legacy font to Unicode
+func ToUnicode(text string, encoding *Encoding) string {
+	var converted strings.Builder
+	for len(text) > 0 {
+		if sequence, replacement := longestSequence(text, encoding); sequence != "" {
+			converted.WriteString(replacement)
+			text = text[len(sequence):]
+			continue
+		}
+		character, size := utf8.DecodeRuneInString(text)
+		if mapped, ok := encoding.Characters[character]; ok {
+			converted.WriteString(mapped)
+		} else {
+			converted.WriteRune(character)
+		}
+		text = text[size:]
+	}
+	var result = []rune(converted.String())
+	result = placeIKar(result)
+	result = placeReph(result)
+	return strings.NewReplacer(
+		//half letters followed by ा are full letters, e.g. क्ष्ा is क्ष
+		"्ा", "",
+		"अाे", "ओ", "अाै", "औ", "अा", "आ", "एे", "ऐ",
+		string(reph), "र्",
+	).Replace(string(result))
+}
+
+func longestSequence(text string, encoding *Encoding) (string, string) {
+	var found, replacement string
+	for sequence, value := range encoding.Sequences {
+		if len(sequence) > len(found) && strings.HasPrefix(text, sequence) {
+			found, replacement = sequence, value
+		}
+	}
+	return found, replacement
+}
+
+func isConsonant(character rune) bool {
+	return (character >= 'क' && character <= 'ह') || (character >= '\u0958' && character <= '\u095F')
+}
+
+// consonantCluster returns the length of the consonants starting at i, joined with halants, e.g. स्त
+func consonantCluster(text []rune, i int) int {
+	var length = 0
+	for i+length < len(text) && isConsonant(text[i+length]) {
+		length++
+		if i+length+1 < len(text) && text[i+length] == '्' && isConsonant(text[i+length+1]) {
+			length++
+			continue
+		}
+		break
+	}
+	return length
+}
+
+// placeIKar moves ि behind the consonant it belongs to, the legacy fonts type it first because it is drawn first
+func placeIKar(text []rune) []rune {
+	for i := 0; i < len(text); i++ {
+		if text[i] != 'ि' {
+			continue
+		}
+		var length = consonantCluster(text, i+1)
+		if length == 0 {
+			continue
+		}
+		copy(text[i:], text[i+1:i+1+length])
+		text[i+length] = 'ि'
+		i += length
+	}
+	return text
+}
+
+// placeReph moves the reph in front of the consonant it belongs to, the legacy fonts type it after the syllable
+func placeReph(text []rune) []rune {
+	for i := 0; i < len(text); i++ {
+		if text[i] != reph {
+			continue
+		}
+		//skip the vowel signs back to the consonant
+		var start = i
+		for start > 0 && isVowelSign(text[start-1]) {
+			start--
+		}
+		if start == 0 || !isConsonant(text[start-1]) {
+			continue
+		}
+		start--
+		//the consonant might be the end of a cluster like स्त
+		for start >= 2 && text[start-1] == '्' && isConsonant(text[start-2]) {
+			start -= 2
+		}
+		copy(text[start+1:i+1], text[start:i])
+		text[start] = reph
+	}
+	return text
+}
+
+func isVowelSign(character rune) bool {
+	return (character >= 'ा' && character <= 'ौ') || character == 'ं' || character == 'ँ'
+}
+
+// layouts are tried in this order by ParseDate. There is no layout with hyphens,
+// a '-' typed with Preeti is a "(" and not a separator of dates
+var layouts = []string{
+	"YYYY साल MMMM D गते", "YYYY MMMM D गते", "YYYY MMMM D", "D MMMM YYYY",
+	"YYYY/M/D", "YYYY.M.D",
+}
+
+// ParseDate converts the text and reads the BS date in it. The text may start with मिति (date)
+// and end with a ।, the date can be written like २०६८ साल बैशाख १५ गते, २०६८ बैशाख १५ or २०६८/१/१५
+func ParseDate(text string, encoding *Encoding) (bsdate.Date, error) {
+	var converted = strings.TrimSpace(ToUnicode(text, encoding))
+	var original = converted
+	converted = strings.TrimSpace(strings.TrimSuffix(converted, "।"))
+	converted = strings.TrimSpace(strings.TrimPrefix(converted, "मिति"))
+	converted = strings.TrimSpace(strings.TrimPrefix(converted, ":"))
+	for _, layout := range layouts {
+		d, err := bsdate.ParseLocale(layout, converted, bsdate.Nepali)
+		if err == nil {
+			return d, nil
+		}
+		if err == bsdate.ErrInvalidDate || err == bsdate.ErrMissingData {
+			return nil, err
+		}
+	}
+	return nil, errors.New("\"" + original + "\" is no date")
+}
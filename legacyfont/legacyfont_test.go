@@ -0,0 +1,74 @@
+package legacyfont
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+)
+
+func TestToUnicode(t *testing.T) {
+	var tests = []struct {
+		preeti   string
+		expected string
+	}{
+		{"@)^* ;fn a}zfv !% ut]", "२०६८ साल बैशाख १५ गते"},
+		{"sflt{s", "कार्तिक"},
+		{"wd{", "धर्म"},
+		{"k|b]z", "प्रदेश"},
+		{"lbg", "दिन"},
+		{"ldlt @)^*÷!÷!%", "मिति २०६८/१/१५"},
+		{"cfh", "आज"},
+		{"cf]v/", "ओखर"},
+		{"c;f/ r}t df3", "असार चैत माघ"},
+		{"Ifdf", "क्षमा"},
+		{"ABC 123", "ब्द्यऋ ज्ञद्दघ"},
+	}
+	for _, test := range tests {
+		t.Run(test.preeti, func(t *testing.T) {
+			assert.Equal(t, ToUnicode(test.preeti, Preeti), test.expected)
+			assert.Equal(t, ToUnicode(test.preeti, Kantipur), test.expected)
+		})
+	}
+}
+
+func TestToUnicodeKeepsUnicode(t *testing.T) {
+	assert.Equal(t, ToUnicode("२०६८ ;fn", Preeti), "२०६८ साल")
+}
+
+func TestParseDate(t *testing.T) {
+	var tests = []struct {
+		preeti   string
+		expected string
+	}{
+		{"@)^* ;fn a}zfv !% ut]", "2068-01-15"},
+		{"@)^* ;fn", ""},
+		{"  @)^* a}zfv !% ut] .", "2068-01-15"},
+		{"ldlt @)^*÷!÷!%", "2068-01-15"},
+		{"@)*! sflt{s #", "2081-07-03"},
+		{"!% c;f/ @)*!", "2081-03-15"},
+		{"@)*!=)#=#@", "2081-03-32"},
+	}
+	for _, test := range tests {
+		t.Run(test.preeti, func(t *testing.T) {
+			d, err := ParseDate(test.preeti, Preeti)
+			if test.expected == "" {
+				assert.Equal(t, err != nil, true)
+				return
+			}
+			assert.Equal(t, err, nil)
+			assert.Equal(t, fmt.Sprintf("%04d-%02d-%02d", d.GetYear(), d.GetMonth(), d.GetDay()), test.expected)
+		})
+	}
+}
+
+func TestParseDateErrors(t *testing.T) {
+	_, err := ParseDate("@)^* ;fn a}zfv #% ut]", Preeti)
+	assert.Equal(t, err, bsdate.ErrInvalidDate)
+	_, err = ParseDate(";fn", Preeti)
+	assert.Equal(t, err.Error(), "\"साल\" is no date")
+	_, err = ParseDate("@)*!-#-@", Preeti)
+	assert.Equal(t, err.Error(), "\"२०८१(३(२\" is no date")
+}
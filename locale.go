@@ -0,0 +1,254 @@
+package bsdate
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DateOrder is the order in which a locale writes the parts of a date
+type DateOrder int
+
+const (
+	// DayMonthYear writes dates like 15 Baisakh 2081
+	DayMonthYear DateOrder = iota
+	// YearMonthDay writes dates like २०८१ बैशाख १५
+	YearMonthDay
+	// MonthDayYear writes dates like Baisakh 15, 2081
+	MonthDayYear
+)
+
+// Locale has the words, digits and conventions used to write dates in a language.
+// The locales of this package are registered, others can be added with RegisterLocale.
+// Functions that take a locale use English if it is nil
+type Locale struct {
+	// Code identifies the locale, e.g. "ne", the codes of ISO 639 are used
+	Code string
+	// Name is the english name of the language
+	Name string
+	// Digits are the digits 0-9
+	Digits [10]rune
+	// MonthNames are the names of the BS months starting with Baisakh
+	MonthNames [12]string
+	// ShortMonthNames are written by the layout token MMM, MonthNames are used if they are empty
+	ShortMonthNames [12]string
+	// FormalMonthNames are used in formal texts like legal documents, e.g. चैत्र instead of चैत
+	FormalMonthNames [12]string
+	// MonthAliases are other spellings of month names that are understood when parsing, with their month number
+	MonthAliases map[string]int
+	// WeekdayNames and ShortWeekdayNames start with Sunday
+	WeekdayNames      [7]string
+	ShortWeekdayNames [7]string
+	// Order is the order in which day, month and year are written
+	Order DateOrder
+	// Relative has the words used by Relative, the english words are used if it is empty
+	Relative RelativeWords
+	// NumberWords spells numbers, it is nil if the locale cannot write numbers in words
+	NumberWords *NumberWords
+	// DateWordsLayout places the words of the year, the month and the day, see DateToWords
+	DateWordsLayout string
+}
+
+// RelativeWords are the phrases Relative builds its text from.
+// Phrases with %s get the number, the distance or the name of a month
+type RelativeWords struct {
+	Today, Tomorrow, Yesterday string
+	NextMonth, LastMonth       string
+	NextYear, LastYear         string
+	// NextNamedMonth and LastNamedMonth get the name of a month, e.g. "next %s"
+	NextNamedMonth, LastNamedMonth string
+	// Future and Past get the distance, e.g. "in %s" and "%s ago"
+	Future, Past               string
+	Days, Weeks, Months, Years Plural
+}
+
+// Plural has the singular and the plural form of a phrase
+type Plural struct {
+	One, Other string
+}
+
+// For returns the form to use for n things
+func (p Plural) For(n int) string {
+	if n == 1 || n == -1 {
+		return p.One
+	}
+	return p.Other
+}
+
+// FormatNumber writes n with the digits of the locale
+func (l *Locale) FormatNumber(n int) string {
+	return l.toLocalDigits(strconv.Itoa(n))
+}
+
+// toLocalDigits replaces 0-9 in s by the digits of the locale
+func (l *Locale) toLocalDigits(s string) string {
+	return strings.Map(func(character rune) rune {
+		if character >= '0' && character <= '9' {
+			return l.Digits[character-'0']
+		}
+		return character
+	}, s)
+}
+
+// Layout returns the layout for Format that writes a date with the month name in the order of the locale
+func (l *Locale) Layout() string {
+	switch l.Order {
+	case YearMonthDay:
+		return "YYYY MMMM D"
+	case MonthDayYear:
+		return "MMMM D, YYYY"
+	}
+	return "D MMMM YYYY"
+}
+
+// MonthNumber returns the number of the month with the given name, abbreviation, formal name or alias.
+// Case is ignored
+func (l *Locale) MonthNumber(name string) (int, bool) {
+	for _, candidate := range l.monthNames() {
+		if strings.EqualFold(candidate.name, name) {
+			return candidate.month, true
+		}
+	}
+	return 0, false
+}
+
+type monthName struct {
+	name  string
+	month int
+}
+
+// monthNames lists all names of all months of the locale, the longest names first
+func (l *Locale) monthNames() []monthName {
+	var names []monthName
+	for i := 0; i < 12; i++ {
+		for _, name := range []string{l.MonthNames[i], l.ShortMonthNames[i], l.FormalMonthNames[i]} {
+			if name != "" {
+				names = append(names, monthName{name, i + 1})
+			}
+		}
+	}
+	for name, month := range l.MonthAliases {
+		names = append(names, monthName{name, month})
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		if len(names[i].name) != len(names[j].name) {
+			return len(names[i].name) > len(names[j].name)
+		}
+		return names[i].name < names[j].name
+	})
+	return names
+}
+
+func (l *Locale) shortMonthName(month int) string {
+	if l.ShortMonthNames[month-1] != "" {
+		return l.ShortMonthNames[month-1]
+	}
+	return l.MonthNames[month-1]
+}
+
+// orEnglish returns l, or English if l is nil
+func orEnglish(l *Locale) *Locale {
+	if l == nil {
+		return English
+	}
+	return l
+}
+
+func (l *Locale) relativeWords() RelativeWords {
+	if l.Relative.Today == "" {
+		return English.Relative
+	}
+	return l.Relative
+}
+
+// toASCIIDigits replaces the digits of the locale in s by 0-9
+func (l *Locale) toASCIIDigits(s string) string {
+	return strings.Map(func(character rune) rune {
+		for digit, localDigit := range l.Digits {
+			if character == localDigit {
+				return '0' + rune(digit)
+			}
+		}
+		return character
+	}, s)
+}
+
+var (
+	localesMutex sync.RWMutex
+	locales      = map[string]*Locale{}
+	//localeOrder keeps the order of registration, so month names are resolved in a predictable way
+	localeOrder []string
+)
+
+func init() {
+	for _, locale := range []*Locale{English, Nepali, Hindi, Maithili, Bhojpuri, Newar, Tamang} {
+		if err := RegisterLocale(locale); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterLocale adds a locale, so it can be found by LookupLocale and its month names by ResolveMonthName.
+// The locale must not be changed after registering it
+func RegisterLocale(locale *Locale) error {
+	if locale.Code == "" {
+		return errors.New("the locale needs a code")
+	}
+	for i, name := range locale.MonthNames {
+		if name == "" {
+			return errors.New("locale " + locale.Code + " has no name for month " + strconv.Itoa(i+1))
+		}
+	}
+	for i, name := range locale.WeekdayNames {
+		if name == "" {
+			return errors.New("locale " + locale.Code + " has no name for weekday " + strconv.Itoa(i))
+		}
+	}
+	for name, month := range locale.MonthAliases {
+		if month < 1 || month > 12 {
+			return errors.New("locale " + locale.Code + " has the alias " + name + " for the invalid month " + strconv.Itoa(month))
+		}
+	}
+	if locale.Digits == [10]rune{} {
+		return errors.New("locale " + locale.Code + " has no digits")
+	}
+	localesMutex.Lock()
+	defer localesMutex.Unlock()
+	if _, exists := locales[locale.Code]; exists {
+		return errors.New("there is already a locale " + locale.Code)
+	}
+	locales[locale.Code] = locale
+	localeOrder = append(localeOrder, locale.Code)
+	return nil
+}
+
+// LookupLocale returns the registered locale with the code
+func LookupLocale(code string) (*Locale, bool) {
+	localesMutex.RLock()
+	defer localesMutex.RUnlock()
+	locale, ok := locales[code]
+	return locale, ok
+}
+
+// LocaleCodes returns the codes of all registered locales in alphabetical order
+func LocaleCodes() []string {
+	localesMutex.RLock()
+	defer localesMutex.RUnlock()
+	var codes = append([]string(nil), localeOrder...)
+	sort.Strings(codes)
+	return codes
+}
+
+// ResolveMonthName finds the month number of a name in any registered locale, see Locale.MonthNumber
+func ResolveMonthName(name string) (int, bool) {
+	localesMutex.RLock()
+	defer localesMutex.RUnlock()
+	for _, code := range localeOrder {
+		if month, ok := locales[code].MonthNumber(name); ok {
+			return month, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,129 @@
+package bsdate
+
+import (
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestPlural(t *testing.T) {
+	var days = Plural{"%s day", "%s days"}
+	assert.Equal(t, days.For(1), "%s day")
+	assert.Equal(t, days.For(-1), "%s day")
+	assert.Equal(t, days.For(0), "%s days")
+	assert.Equal(t, days.For(2), "%s days")
+}
+
+func TestFormatNumber(t *testing.T) {
+	assert.Equal(t, English.FormatNumber(2081), "2081")
+	assert.Equal(t, Nepali.FormatNumber(2081), "२०८१")
+	assert.Equal(t, Nepali.FormatNumber(-15), "-१५")
+}
+
+func TestLookupLocale(t *testing.T) {
+	assert.Equal(t, LocaleCodes(), []string{"bho", "en", "hi", "mai", "ne", "new", "tmg"})
+	locale, ok := LookupLocale("ne")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, locale, Nepali)
+	_, ok = LookupLocale("xx")
+	assert.Equal(t, ok, false)
+}
+
+func TestRegisterLocale(t *testing.T) {
+	var sherpa = &Locale{
+		Code:         "xsr",
+		Name:         "Sherpa",
+		Digits:       Nepali.Digits,
+		MonthNames:   Nepali.MonthNames,
+		WeekdayNames: [7]string{"आइतबार", "सोमबार", "मंगलबार", "बुधबार", "बिहीबार", "शुक्रबार", "शनिबार"},
+		Order:        YearMonthDay,
+	}
+	assert.Equal(t, RegisterLocale(sherpa), nil)
+	defer func() {
+		localesMutex.Lock()
+		delete(locales, "xsr")
+		localeOrder = localeOrder[:len(localeOrder)-1]
+		localesMutex.Unlock()
+	}()
+	locale, ok := LookupLocale("xsr")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, FormatLocale(mustNew(t, "2081-01-01"), locale.Layout()+", dddd", locale), "२०८१ बैशाख १, शनिबार")
+	//the english words are used for missing phrases
+	assert.Equal(t, relativeForTest(t, mustNew(t, "2081-01-01"), mustNew(t, "2081-01-04"), locale, DefaultRelativeOptions), "in ३ days")
+
+	assert.Equal(t, RegisterLocale(sherpa).Error(), "there is already a locale xsr")
+	assert.Equal(t, RegisterLocale(&Locale{}).Error(), "the locale needs a code")
+	var incomplete = *sherpa
+	incomplete.Code = "xx"
+	incomplete.MonthNames[11] = ""
+	assert.Equal(t, RegisterLocale(&incomplete).Error(), "locale xx has no name for month 12")
+	var wrongAlias = *sherpa
+	wrongAlias.Code = "xx"
+	wrongAlias.MonthAliases = map[string]int{"Foo": 13}
+	assert.Equal(t, RegisterLocale(&wrongAlias).Error(), "locale xx has the alias Foo for the invalid month 13")
+}
+
+func TestMonthNumber(t *testing.T) {
+	var tests = []struct {
+		locale   *Locale
+		name     string
+		expected int
+	}{
+		{English, "Shrawan", 4},
+		{English, "saun", 4},
+		{English, "CHA", 12},
+		{English, "Magh", 10},
+		{Nepali, "साउन", 4},
+		{Nepali, "श्रावण", 4},
+		{Nepali, "मङ्सिर", 8},
+		{Hindi, "सावन", 4},
+		{Maithili, "अगहन", 8},
+		{Bhojpuri, "चइत", 12},
+		{Newar, "पुस", 9},
+		{Tamang, "माघ", 10},
+		{Nepali, "Shrawan", 0},
+	}
+	for _, test := range tests {
+		t.Run(test.locale.Code+" "+test.name, func(t *testing.T) {
+			month, ok := test.locale.MonthNumber(test.name)
+			assert.Equal(t, ok, test.expected != 0)
+			assert.Equal(t, month, test.expected)
+		})
+	}
+}
+
+// a name must not mean different months in different locales, otherwise ResolveMonthName would depend on the order
+func TestMonthNamesAreUnambiguous(t *testing.T) {
+	var months = map[string]int{}
+	for _, code := range LocaleCodes() {
+		locale, _ := LookupLocale(code)
+		for _, name := range locale.monthNames() {
+			if month, ok := months[name.name]; ok && month != name.month {
+				t.Errorf("%s is month %d in %s but month %d in another locale", name.name, name.month, code, month)
+			}
+			months[name.name] = name.month
+		}
+	}
+}
+
+func TestNewWithLocalizedMonthNames(t *testing.T) {
+	d, err := New(1, "Magh", 2081)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, d.GetMonth(), 10)
+	for _, name := range []string{"Ashadh", "asar", "असार", "आषाढ़", "अखाढ़"} {
+		d, err := New(15, name, 2081)
+		assert.Equal(t, err, nil)
+		assert.Equal(t, d.GetMonth(), 3)
+		assert.Equal(t, d.GetMonthName(), "Ashadh")
+	}
+}
+
+func TestLocaleLayout(t *testing.T) {
+	var d = mustNew(t, "2081-01-15")
+	assert.Equal(t, FormatLocale(d, English.Layout(), English), "15 Baisakh 2081")
+	assert.Equal(t, FormatLocale(d, Nepali.Layout(), Nepali), "२०८१ बैशाख १५")
+	assert.Equal(t, FormatLocale(d, Hindi.Layout(), Hindi), "१५ वैशाख २०८१")
+	assert.Equal(t, FormatLocale(d, Tamang.Layout()+", dddd", Tamang), "२०८१ बैशाख १५, पेम्बा")
+	var american = &Locale{Order: MonthDayYear}
+	assert.Equal(t, american.Layout(), "MMMM D, YYYY")
+}
@@ -0,0 +1,173 @@
+package bsdate
+
+var (
+	asciiDigits      = [10]rune{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9'}
+	devanagariDigits = [10]rune{'०', '१', '२', '३', '४', '५', '६', '७', '८', '९'}
+	//the sanskrit names are used in formal texts of all languages written in Devanagari
+	formalMonthNames = [12]string{
+		"वैशाख", "ज्येष्ठ", "आषाढ", "श्रावण", "भाद्र", "आश्विन", "कार्तिक", "मार्गशीर्ष", "पौष", "माघ", "फाल्गुन", "चैत्र",
+	}
+)
+
+// English writes dates with the romanized month names, it is used by Format and Parse
+var English = &Locale{
+	Code:             "en",
+	Name:             "English",
+	Digits:           asciiDigits,
+	MonthNames:       MonthNames,
+	ShortMonthNames:  [12]string{"Bai", "Jes", "Ash", "Shr", "Bha", "Asw", "Kar", "Man", "Pau", "Mag", "Fal", "Cha"},
+	FormalMonthNames: MonthNames,
+	MonthAliases: map[string]int{
+		"Baishakh": 1, "Vaisakh": 1, "Jeth": 2, "Jyestha": 2, "Asar": 3, "Ashar": 3, "Asadh": 3,
+		"Saun": 4, "Sawan": 4, "Shravan": 4, "Bhadau": 5, "Asoj": 6, "Ashoj": 6, "Aswin": 6,
+		"Kattik": 7, "Mansir": 8, "Marga": 8, "Poush": 9, "Pus": 9, "Push": 9, "Magh": 10,
+		"Phalgun": 11, "Fagun": 11, "Phagun": 11, "Chait": 12,
+	},
+	WeekdayNames:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+	ShortWeekdayNames: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	Order:             DayMonthYear,
+	Relative: RelativeWords{
+		Today: "today", Tomorrow: "tomorrow", Yesterday: "yesterday",
+		NextMonth: "next month", LastMonth: "last month",
+		NextYear: "next year", LastYear: "last year",
+		NextNamedMonth: "next %s", LastNamedMonth: "last %s",
+		Future: "in %s", Past: "%s ago",
+		Days:   Plural{"%s day", "%s days"},
+		Weeks:  Plural{"%s week", "%s weeks"},
+		Months: Plural{"%s month", "%s months"},
+		Years:  Plural{"%s year", "%s years"},
+	},
+	NumberWords: &NumberWords{
+		Small: []string{
+			"Zero", "One", "Two", "Three", "Four", "Five", "Six", "Seven", "Eight", "Nine", "Ten",
+			"Eleven", "Twelve", "Thirteen", "Fourteen", "Fifteen", "Sixteen", "Seventeen", "Eighteen", "Nineteen",
+		},
+		Tens:          [10]string{"", "", "Twenty", "Thirty", "Forty", "Fifty", "Sixty", "Seventy", "Eighty", "Ninety"},
+		TensSeparator: "-",
+		Hundred:       "Hundred",
+		Scales:        []NumberScale{{1000000000, "Billion"}, {1000000, "Million"}, {1000, "Thousand"}},
+		Minus:         "Minus",
+	},
+	DateWordsLayout: "{year}, {month} {day}",
+}
+
+// Nepali writes dates in Devanagari with the month names used in everyday speech
+var Nepali = &Locale{
+	Code:              "ne",
+	Name:              "Nepali",
+	Digits:            devanagariDigits,
+	MonthNames:        [12]string{"बैशाख", "जेठ", "असार", "साउन", "भदौ", "असोज", "कात्तिक", "मंसिर", "पुस", "माघ", "फागुन", "चैत"},
+	FormalMonthNames:  formalMonthNames,
+	MonthAliases:      map[string]int{"जेष्ठ": 2, "असाढ": 3, "श्रावन": 4, "मङ्सिर": 8, "मार्ग": 8},
+	WeekdayNames:      [7]string{"आइतबार", "सोमबार", "मंगलबार", "बुधबार", "बिहीबार", "शुक्रबार", "शनिबार"},
+	ShortWeekdayNames: [7]string{"आइत", "सोम", "मंगल", "बुध", "बिही", "शुक्र", "शनि"},
+	Order:             YearMonthDay,
+	Relative: RelativeWords{
+		Today: "आज", Tomorrow: "भोलि", Yesterday: "हिजो",
+		NextMonth: "अर्को महिना", LastMonth: "गत महिना",
+		NextYear: "अर्को वर्ष", LastYear: "गत वर्ष",
+		NextNamedMonth: "आउँदो %s", LastNamedMonth: "गत %s",
+		Future: "%s पछि", Past: "%s अगाडि",
+		Days:   Plural{"%s दिन", "%s दिन"},
+		Weeks:  Plural{"%s हप्ता", "%s हप्ता"},
+		Months: Plural{"%s महिना", "%s महिना"},
+		Years:  Plural{"%s वर्ष", "%s वर्ष"},
+	},
+	NumberWords: &NumberWords{
+		//the names of 1 to 99 are irregular, so all of them are listed
+		Small: []string{
+			"शून्य", "एक", "दुई", "तीन", "चार", "पाँच", "छ", "सात", "आठ", "नौ",
+			"दश", "एघार", "बाह्र", "तेह्र", "चौध", "पन्ध्र", "सोह्र", "सत्र", "अठार", "उन्नाइस",
+			"बीस", "एक्काइस", "बाइस", "तेइस", "चौबीस", "पच्चीस", "छब्बीस", "सत्ताइस", "अठ्ठाइस", "उनन्तीस",
+			"तीस", "एकतीस", "बत्तीस", "तेत्तीस", "चौंतीस", "पैंतीस", "छत्तीस", "सैंतीस", "अठतीस", "उनन्चालीस",
+			"चालीस", "एकचालीस", "बयालीस", "त्रियालीस", "चवालीस", "पैंतालीस", "छयालीस", "सतचालीस", "अठचालीस", "उनन्चास",
+			"पचास", "एकाउन्न", "बाउन्न", "त्रिपन्न", "चउन्न", "पचपन्न", "छपन्न", "सन्ताउन्न", "अन्ठाउन्न", "उनन्साठी",
+			"साठी", "एकसट्ठी", "बयसट्ठी", "त्रिसट्ठी", "चौंसट्ठी", "पैंसट्ठी", "छयसट्ठी", "सतसट्ठी", "अठसट्ठी", "उनन्सत्तरी",
+			"सत्तरी", "एकहत्तर", "बहत्तर", "त्रिहत्तर", "चौहत्तर", "पचहत्तर", "छयहत्तर", "सतहत्तर", "अठहत्तर", "उनासी",
+			"असी", "एकासी", "बयासी", "त्रियासी", "चौरासी", "पचासी", "छयासी", "सतासी", "अठासी", "उनान्नब्बे",
+			"नब्बे", "एकान्नब्बे", "बयान्नब्बे", "त्रियान्नब्बे", "चौरान्नब्बे", "पन्चानब्बे", "छयान्नब्बे", "सन्तान्नब्बे", "अन्ठान्नब्बे", "उनान्सय",
+		},
+		Hundred: "सय",
+		Scales: []NumberScale{
+			{100000000000, "खर्ब"}, {1000000000, "अर्ब"}, {10000000, "करोड"}, {100000, "लाख"}, {1000, "हजार"},
+		},
+		Minus:   "ऋण",
+		Aliases: map[string]string{"दस": "दश"},
+	},
+	DateWordsLayout: "{year} साल {month} {day} गते",
+}
+
+// Hindi writes dates with the month names of the Vikram Samvat as used in India
+var Hindi = &Locale{
+	Code:             "hi",
+	Name:             "Hindi",
+	Digits:           devanagariDigits,
+	MonthNames:       [12]string{"वैशाख", "ज्येष्ठ", "आषाढ़", "श्रावण", "भाद्रपद", "आश्विन", "कार्तिक", "मार्गशीर्ष", "पौष", "माघ", "फाल्गुन", "चैत्र"},
+	FormalMonthNames: formalMonthNames,
+	MonthAliases: map[string]int{
+		"जेठ": 2, "असाढ़": 3, "सावन": 4, "भादो": 5, "क्वार": 6, "अगहन": 8, "पूस": 9, "फागुन": 11, "चैत": 12,
+	},
+	WeekdayNames:      [7]string{"रविवार", "सोमवार", "मंगलवार", "बुधवार", "गुरुवार", "शुक्रवार", "शनिवार"},
+	ShortWeekdayNames: [7]string{"रवि", "सोम", "मंगल", "बुध", "गुरु", "शुक्र", "शनि"},
+	Order:             DayMonthYear,
+	Relative: RelativeWords{
+		Today: "आज", Tomorrow: "कल", Yesterday: "कल",
+		NextMonth: "अगले महीने", LastMonth: "पिछले महीने",
+		NextYear: "अगले साल", LastYear: "पिछले साल",
+		NextNamedMonth: "अगले %s", LastNamedMonth: "पिछले %s",
+		Future: "%s बाद", Past: "%s पहले",
+		Days:   Plural{"%s दिन", "%s दिन"},
+		Weeks:  Plural{"%s सप्ताह", "%s सप्ताह"},
+		Months: Plural{"%s महीना", "%s महीने"},
+		Years:  Plural{"%s साल", "%s साल"},
+	},
+}
+
+// Maithili writes dates with the month names used in Mithila
+var Maithili = &Locale{
+	Code:              "mai",
+	Name:              "Maithili",
+	Digits:            devanagariDigits,
+	MonthNames:        [12]string{"बैसाख", "जेठ", "अखाढ़", "साओन", "भादब", "आसिन", "कातिक", "अगहन", "पूस", "माघ", "फागुन", "चैत"},
+	FormalMonthNames:  formalMonthNames,
+	WeekdayNames:      [7]string{"रविदिन", "सोमदिन", "मंगलदिन", "बुधदिन", "बृहस्पतिदिन", "शुक्रदिन", "शनिदिन"},
+	ShortWeekdayNames: [7]string{"रवि", "सोम", "मंगल", "बुध", "बृहस्पति", "शुक्र", "शनि"},
+	Order:             YearMonthDay,
+}
+
+// Bhojpuri writes dates with the month names used in Bhojpuri
+var Bhojpuri = &Locale{
+	Code:              "bho",
+	Name:              "Bhojpuri",
+	Digits:            devanagariDigits,
+	MonthNames:        [12]string{"बैसाख", "जेठ", "असाढ़", "सावन", "भादो", "कुआर", "कातिक", "अगहन", "पूस", "माघ", "फागुन", "चइत"},
+	FormalMonthNames:  formalMonthNames,
+	WeekdayNames:      [7]string{"एतवार", "सोमार", "मंगर", "बुध", "बियफे", "सुक", "सनिचर"},
+	ShortWeekdayNames: [7]string{"एतवार", "सोमार", "मंगर", "बुध", "बियफे", "सुक", "सनिचर"},
+	Order:             YearMonthDay,
+}
+
+// Newar writes dates in Nepal Bhasa with Devanagari, it uses the Nepali names of the BS months
+var Newar = &Locale{
+	Code:              "new",
+	Name:              "Newar",
+	Digits:            devanagariDigits,
+	MonthNames:        Nepali.MonthNames,
+	FormalMonthNames:  formalMonthNames,
+	WeekdayNames:      [7]string{"आइतबाः", "सोमबाः", "मंगलबाः", "बुधबाः", "बिहिबाः", "सुक्रबाः", "शनिबाः"},
+	ShortWeekdayNames: [7]string{"आइत", "सोम", "मंगल", "बुध", "बिहि", "सुक्र", "शनि"},
+	Order:             YearMonthDay,
+}
+
+// Tamang writes dates in Devanagari with the Nepali names of the BS months and the Tamang weekday names,
+// which come from Tibetan
+var Tamang = &Locale{
+	Code:              "tmg",
+	Name:              "Tamang",
+	Digits:            devanagariDigits,
+	MonthNames:        Nepali.MonthNames,
+	FormalMonthNames:  formalMonthNames,
+	WeekdayNames:      [7]string{"ङिमा", "दावा", "मिङमार", "ल्हाक्पा", "फुर्बा", "पासाङ", "पेम्बा"},
+	ShortWeekdayNames: [7]string{"ङिमा", "दावा", "मिङमार", "ल्हाक्पा", "फुर्बा", "पासाङ", "पेम्बा"},
+	Order:             YearMonthDay,
+}
@@ -0,0 +1,371 @@
+package bsdate
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrLunarDayNotFound = errors.New("lunar day does not occur in that year")
+
+// Paksha is one of the two fortnights of a lunar month
+type Paksha int
+
+const (
+	// Shukla is the bright fortnight, from new moon to full moon
+	Shukla Paksha = iota
+	// Krishna is the dark fortnight, from full moon to new moon
+	Krishna
+)
+
+var PakshaNames = [2]string{"Shukla", "Krishna"}
+
+// TithiNames are the names of the 15 tithis of a paksha, the last tithi of the Krishna paksha is Aunsi (Amavasya)
+var TithiNames = [15]string{
+	"Pratipada", "Dwitiya", "Tritiya", "Chaturthi", "Panchami", "Shashthi", "Saptami", "Ashtami",
+	"Navami", "Dashami", "Ekadashi", "Dwadashi", "Trayodashi", "Chaturdashi", "Purnima",
+}
+
+func (p Paksha) String() string {
+	if p == Krishna {
+		return PakshaNames[1]
+	}
+	return PakshaNames[0]
+}
+
+// Observance is the moment of a day at which the tithi is checked
+type Observance int
+
+const (
+	// AtSunrise uses the tithi prevailing at sunrise (udaya tithi), which is the tithi of the day in the calendar
+	AtSunrise Observance = iota
+	// AtAfternoon uses the tithi at the aparahna, the fourth fifth of the daytime
+	AtAfternoon
+	// AtEvening uses the tithi at the pradosh, shortly after sunset
+	AtEvening
+	// AtMidnight uses the tithi at the nishita, halfway between sunset and the next sunrise
+	AtMidnight
+)
+
+var observanceNames = [4]string{"sunrise", "afternoon", "evening", "midnight"}
+
+// String returns the name of the observance, or "invalid" for values that are not one of the constants
+func (o Observance) String() string {
+	if o < 0 || int(o) >= len(observanceNames) {
+		return "invalid"
+	}
+	return observanceNames[o]
+}
+
+// moment returns the julian day of the observance on the given gregorian day in Kathmandu
+func (o Observance) moment(day time.Time) float64 {
+	var rise, set = sunTimes(day)
+	switch o {
+	case AtAfternoon:
+		return rise + 0.7*(set-rise)
+	case AtEvening:
+		return set + 0.8/24
+	case AtMidnight:
+		return set + (1-(set-rise))/2
+	}
+	return rise
+}
+
+// LunarDate is the position of a day in the lunar calendar, as it is used for festivals.
+// Months start with the new moon, but like it is common in Nepal the Krishna paksha is named after the following month,
+// e.g. the Krishna paksha after Ashwin Purnima is "Kartik Krishna".
+// The month uses the same numbering as the BS solar months (1 for Baisakh).
+type LunarDate struct {
+	Month int
+	// Adhik is true for an intercalary month, a lunar month during which the sun does not enter a new rashi
+	Adhik  bool
+	Paksha Paksha
+	// Tithi goes from 1 (Pratipada) to 15 (Purnima or Aunsi)
+	Tithi int
+}
+
+// TithiName returns the name of the tithi, or "invalid" if it is not between 1 and 15
+func (l LunarDate) TithiName() string {
+	if l.Tithi < 1 || l.Tithi > 15 {
+		return "invalid"
+	}
+	if l.Paksha == Krishna && l.Tithi == 15 {
+		return "Aunsi"
+	}
+	return TithiNames[l.Tithi-1]
+}
+
+// String returns e.g. "Ashwin Shukla Dashami" or "Adhik Shrawan Krishna Ashtami",
+// "invalid" is returned if the month or the tithi are out of range
+func (l LunarDate) String() string {
+	if l.Month < 1 || l.Month > 12 || l.Tithi < 1 || l.Tithi > 15 {
+		return "invalid"
+	}
+	var name = MonthNames[l.Month-1] + " " + l.Paksha.String() + " " + l.TithiName()
+	if l.Adhik {
+		return "Adhik " + name
+	}
+	return name
+}
+
+// Lunar returns the lunar date of d, the tithi is the one prevailing at sunrise in Kathmandu
+func Lunar(d Date) (LunarDate, error) {
+	gregorianDate, err := d.GetGregorianDate()
+	if err != nil {
+		return LunarDate{}, err
+	}
+	return lunarDayAt(AtSunrise.moment(gregorianDate), &lunationCache{}).lunarDate(), nil
+}
+
+// lunarDay is a day in the amanta lunar calendar, months end with the new moon
+type lunarDay struct {
+	lunation    int //number of new moons since January 2000
+	amantaMonth int //0 for Baisakh
+	adhik       bool
+	tithi       int //1 to 30
+}
+
+func (l lunarDay) lunarDate() LunarDate {
+	if l.tithi <= 15 {
+		return LunarDate{Month: l.amantaMonth + 1, Adhik: l.adhik, Paksha: Shukla, Tithi: l.tithi}
+	}
+	return LunarDate{Month: (l.amantaMonth+1)%12 + 1, Adhik: l.adhik, Paksha: Krishna, Tithi: l.tithi - 15}
+}
+
+// the new moon of 6th January 2000, used to number the lunations
+const referenceNewMoon = 2451550.26
+
+// lunationCache remembers the new moons around the last calculated day, days next to each other mostly share them
+type lunationCache struct {
+	start float64
+	end   float64
+}
+
+func (c *lunationCache) bounds(jd float64) (float64, float64) {
+	if jd < c.start || jd >= c.end {
+		c.start = newMoonBefore(jd)
+		c.end = newMoonNear(c.start + synodicMonth)
+	}
+	return c.start, c.end
+}
+
+func lunarDayAt(jd float64, cache *lunationCache) lunarDay {
+	var start, end = cache.bounds(jd)
+	//the month is named after the rashi the sun is in at the new moon that starts it: Mina for Chaitra, Mesha for Baisakh..
+	//if the sun is still in the same rashi at the next new moon, there was no sankranti and the month is an adhik month
+	var rashiAtStart = int(siderealSunLongitude(start) / 30)
+	var rashiAtEnd = int(siderealSunLongitude(end) / 30)
+	return lunarDay{
+		lunation:    int(math.Floor((start-referenceNewMoon)/synodicMonth + 0.5)),
+		amantaMonth: rashiAtStart,
+		adhik:       rashiAtStart == rashiAtEnd,
+		tithi:       int(lunarElongation(jd)/12) + 1,
+	}
+}
+
+// LunarRule describes a yearly recurring lunar day like "Ashwin Shukla Dashami"
+type LunarRule struct {
+	Month  int
+	Paksha Paksha
+	Tithi  int
+	// Observance tells at which moment of the day the tithi has to prevail, by default at sunrise
+	Observance Observance
+}
+
+// ParseLunarRule reads rules in the form "<month> <paksha> <tithi> [at <observance>]",
+// e.g. "Ashwin Shukla Dashami", "Kartik Krishna 15" or "Falgun Krishna Chaturdashi at midnight".
+// For Purnima and Aunsi the paksha can be left out: "Baisakh Purnima".
+// The observance is one of sunrise, afternoon, evening and midnight
+func ParseLunarRule(rule string) (LunarRule, error) {
+	var fields = strings.Fields(rule)
+	var invalid = errors.New("invalid lunar rule \"" + rule + "\", expected e.g. \"Ashwin Shukla Dashami\"")
+	var result LunarRule
+	if len(fields) > 2 && strings.EqualFold(fields[len(fields)-2], "at") {
+		var found = false
+		for i, name := range observanceNames {
+			if strings.EqualFold(name, fields[len(fields)-1]) {
+				result.Observance, found = Observance(i), true
+			}
+		}
+		if !found {
+			return LunarRule{}, invalid
+		}
+		fields = fields[:len(fields)-2]
+	}
+	if len(fields) != 2 && len(fields) != 3 {
+		return LunarRule{}, invalid
+	}
+	var ok bool
+	if result.Month, ok = English.MonthNumber(fields[0]); !ok {
+		return LunarRule{}, invalid
+	}
+
+	//Purnima and Aunsi already tell the paksha
+	var impliedPaksha = -1
+	var tithi = strings.ToLower(fields[len(fields)-1])
+	switch tithi {
+	case "purnima":
+		result.Tithi, impliedPaksha = 15, int(Shukla)
+	case "aunsi", "amavasya", "amawasya":
+		result.Tithi, impliedPaksha = 15, int(Krishna)
+	default:
+		for i, name := range TithiNames[:14] {
+			if strings.ToLower(name) == tithi {
+				result.Tithi = i + 1
+			}
+		}
+		if number, err := strconv.Atoi(tithi); err == nil && number >= 1 && number <= 15 {
+			result.Tithi = number
+		}
+	}
+	if result.Tithi == 0 {
+		return LunarRule{}, invalid
+	}
+
+	if len(fields) == 2 {
+		if impliedPaksha < 0 {
+			return LunarRule{}, invalid
+		}
+		result.Paksha = Paksha(impliedPaksha)
+		return result, nil
+	}
+	switch strings.ToLower(fields[1]) {
+	case "shukla", "sukla":
+		result.Paksha = Shukla
+	case "krishna":
+		result.Paksha = Krishna
+	default:
+		return LunarRule{}, invalid
+	}
+	if impliedPaksha >= 0 && Paksha(impliedPaksha) != result.Paksha {
+		return LunarRule{}, invalid
+	}
+	return result, nil
+}
+
+func (r LunarRule) String() string {
+	var name = LunarDate{Month: r.Month, Paksha: r.Paksha, Tithi: r.Tithi}.String()
+	if r.Observance != AtSunrise {
+		name += " at " + r.Observance.String()
+	}
+	return name
+}
+
+// Resolve finds the day in the given BS year on which the lunar day of the rule falls.
+// That is the first day with the tithi prevailing at the observance of the rule, adhik months are skipped.
+// If the tithi does not prevail at any observance (kshaya tithi) the day before it is over is returned.
+// The committees publishing the official calendars apply further rules in some years, so a festival might be
+// celebrated a day before or after the calculated date.
+// ErrLunarDayNotFound is returned if the lunar day is not in the year, which happens mostly for lunar days around
+// the turn of the BS year.
+func (r LunarRule) Resolve(year int) (Date, error) {
+	var dates, err = r.resolve(year, true)
+	if err != nil {
+		return nil, err
+	}
+	return dates[0], nil
+}
+
+// ResolveAll is like Resolve, but returns all occurrences of the lunar day in that BS year
+func (r LunarRule) ResolveAll(year int) ([]Date, error) {
+	return r.resolve(year, false)
+}
+
+func (r LunarRule) resolve(year int, firstOnly bool) ([]Date, error) {
+	var targetMonth, targetTithi = r.Month - 1, r.Tithi
+	if r.Paksha == Krishna {
+		targetMonth = (r.Month + 10) % 12
+		targetTithi += 15
+	}
+
+	first, err := New(1, 1, year)
+	if err != nil {
+		return nil, err
+	}
+	daysInChaitra, err := DaysInMonth(year, 12)
+	if err != nil {
+		return nil, err
+	}
+	last, err := New(daysInChaitra, 12, year)
+	if err != nil {
+		return nil, err
+	}
+	days, err := NewClosedRange(first, last)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Date
+	var cache = &lunationCache{}
+	var previous Date
+	var previousIndex int
+	var targetIndex = math.MinInt32
+	for iterator := days.Iterate(); iterator.Next(); {
+		var current = iterator.Date()
+		gregorianDate, err := current.GetGregorianDate()
+		if err != nil {
+			return nil, err
+		}
+		var day = lunarDayAt(r.Observance.moment(gregorianDate), cache)
+		var index = day.lunation*30 + day.tithi - 1
+		if !day.adhik && day.amantaMonth == targetMonth {
+			targetIndex = day.lunation*30 + targetTithi - 1
+		}
+		var found Date
+		if index == targetIndex {
+			found = current
+		} else if previous != nil && previousIndex < targetIndex && index > targetIndex {
+			found = previous
+		}
+		if found != nil && len(result) > 0 {
+			order, err := Compare(result[len(result)-1], found)
+			if err != nil {
+				return nil, err
+			}
+			if order == 0 {
+				found = nil
+			}
+		}
+		if found != nil {
+			result = append(result, found)
+			if firstOnly {
+				break
+			}
+		}
+		previous, previousIndex = current, index
+	}
+	if len(result) == 0 {
+		return nil, ErrLunarDayNotFound
+	}
+	return result, nil
+}
+
+// LunarFestival is a festival that is celebrated on a lunar day
+type LunarFestival struct {
+	Name       string
+	NameNepali string
+	Rule       LunarRule
+}
+
+// DateIn returns the date of the festival in the given BS year
+func (f LunarFestival) DateIn(year int) (Date, error) {
+	return f.Rule.Resolve(year)
+}
+
+// LunarFestivals are major festivals of Nepal that follow the lunar calendar
+var LunarFestivals = []LunarFestival{
+	{"Buddha Jayanti", "बुद्ध जयन्ती", LunarRule{Month: 1, Paksha: Shukla, Tithi: 15}},
+	{"Janai Purnima", "जनै पूर्णिमा", LunarRule{Month: 4, Paksha: Shukla, Tithi: 15}},
+	{"Krishna Janmashtami", "कृष्ण जन्माष्टमी", LunarRule{Month: 5, Paksha: Krishna, Tithi: 8, Observance: AtMidnight}},
+	{"Haritalika Teej", "हरितालिका तीज", LunarRule{Month: 5, Paksha: Shukla, Tithi: 3}},
+	{"Ghatasthapana", "घटस्थापना", LunarRule{Month: 6, Paksha: Shukla, Tithi: 1}},
+	//the tika is put on while Dashami prevails in the afternoon, even if it started after sunrise
+	{"Vijaya Dashami", "विजया दशमी", LunarRule{Month: 6, Paksha: Shukla, Tithi: 10, Observance: AtAfternoon}},
+	{"Laxmi Puja", "लक्ष्मी पूजा", LunarRule{Month: 7, Paksha: Krishna, Tithi: 15, Observance: AtEvening}},
+	{"Bhai Tika", "भाइटीका", LunarRule{Month: 7, Paksha: Shukla, Tithi: 2}},
+	{"Chhath", "छठ", LunarRule{Month: 7, Paksha: Shukla, Tithi: 6}},
+	{"Maha Shivaratri", "महाशिवरात्रि", LunarRule{Month: 11, Paksha: Krishna, Tithi: 14, Observance: AtMidnight}},
+	{"Fagu Purnima", "फागु पूर्णिमा", LunarRule{Month: 11, Paksha: Shukla, Tithi: 15, Observance: AtEvening}},
+}
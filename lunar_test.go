@@ -0,0 +1,152 @@
+package bsdate
+
+import (
+	"github.com/magiconair/properties/assert"
+	"testing"
+)
+
+var lunarDates = []struct {
+	bsDate   string
+	expected string
+}{
+	{"2081-06-26", "Ashwin Shukla Navami"}, //Dashami only started in the late morning, Vijaya Dashami was on this day
+	{"2081-06-27", "Ashwin Shukla Dashami"},
+	{"2081-07-16", "Kartik Krishna Aunsi"},
+	{"2081-07-17", "Kartik Shukla Pratipada"},
+	{"2081-02-10", "Baisakh Shukla Purnima"},
+	{"2081-02-11", "Jestha Krishna Pratipada"}, //the Krishna paksha is named after the following month
+	{"2080-04-10", "Adhik Shrawan Shukla Ashtami"},
+	{"2080-05-04", "Shrawan Shukla Panchami"},
+}
+
+func TestLunar(t *testing.T) {
+	for _, testCase := range lunarDates {
+		t.Run(testCase.bsDate, func(t *testing.T) {
+			lunarDate, err := Lunar(mustNew(t, testCase.bsDate))
+			assert.Equal(t, err, nil)
+			assert.Equal(t, lunarDate.String(), testCase.expected)
+		})
+	}
+}
+
+func TestLunarWithoutGregorianDate(t *testing.T) {
+	_, err := Lunar(mustNew(t, "1970-01-01"))
+	assert.Equal(t, err, ErrMissingData)
+}
+
+var lunarRules = []struct {
+	rule     string
+	expected LunarRule
+}{
+	{"Ashwin Shukla Dashami", LunarRule{Month: 6, Paksha: Shukla, Tithi: 10}},
+	{"ashwin shukla dashami", LunarRule{Month: 6, Paksha: Shukla, Tithi: 10}},
+	{"Kartik Krishna Aunsi", LunarRule{Month: 7, Paksha: Krishna, Tithi: 15}},
+	{"Kartik Aunsi", LunarRule{Month: 7, Paksha: Krishna, Tithi: 15}},
+	{"Kartik Amavasya at evening", LunarRule{Month: 7, Paksha: Krishna, Tithi: 15, Observance: AtEvening}},
+	{"Baisakh Purnima", LunarRule{Month: 1, Paksha: Shukla, Tithi: 15}},
+	{"Bhadra Krishna 8 at midnight", LunarRule{Month: 5, Paksha: Krishna, Tithi: 8, Observance: AtMidnight}},
+	{"Magh Shukla Panchami", LunarRule{Month: 10, Paksha: Shukla, Tithi: 5}},
+}
+
+func TestParseLunarRule(t *testing.T) {
+	for _, testCase := range lunarRules {
+		t.Run(testCase.rule, func(t *testing.T) {
+			rule, err := ParseLunarRule(testCase.rule)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, rule, testCase.expected)
+		})
+	}
+}
+
+var invalidLunarRules = []string{
+	"",
+	"Ashwin",
+	"Ashwin Dashami",
+	"Ashwin Shukla Aunsi",
+	"Ashwin Krishna Purnima",
+	"Ashwin Shukla 16",
+	"Ashwin Middle Dashami",
+	"Someday Shukla Dashami",
+	"Ashwin Shukla Dashami at noon",
+	"Ashwin Shukla Dashami Ekadashi",
+}
+
+func TestParseInvalidLunarRule(t *testing.T) {
+	for _, testCase := range invalidLunarRules {
+		t.Run(testCase, func(t *testing.T) {
+			_, err := ParseLunarRule(testCase)
+			assert.Equal(t, err.Error(), "invalid lunar rule \""+testCase+"\", expected e.g. \"Ashwin Shukla Dashami\"")
+		})
+	}
+}
+
+func TestLunarRuleString(t *testing.T) {
+	assert.Equal(t, LunarRule{Month: 6, Paksha: Shukla, Tithi: 10}.String(), "Ashwin Shukla Dashami")
+	assert.Equal(t, LunarRule{Month: 11, Paksha: Krishna, Tithi: 14, Observance: AtMidnight}.String(), "Falgun Krishna Chaturdashi at midnight")
+	assert.Equal(t, LunarRule{}.String(), "invalid")
+	assert.Equal(t, LunarRule{Month: 6, Tithi: 10, Observance: Observance(7)}.String(), "Ashwin Shukla Dashami at invalid")
+	assert.Equal(t, Observance(7).String(), "invalid")
+	assert.Equal(t, LunarDate{Month: 6, Tithi: 16}.TithiName(), "invalid")
+	for _, festival := range LunarFestivals {
+		rule, err := ParseLunarRule(festival.Rule.String())
+		assert.Equal(t, err, nil)
+		assert.Equal(t, rule, festival.Rule)
+	}
+}
+
+// dates as published in the official calendars
+var festivalDates = []struct {
+	festival string
+	year     int
+	expected string
+}{
+	{"Buddha Jayanti", 2080, "2080-01-22"},
+	{"Buddha Jayanti", 2081, "2081-02-10"},
+	{"Krishna Janmashtami", 2080, "2080-05-20"},
+	{"Haritalika Teej", 2080, "2080-06-01"},
+	{"Haritalika Teej", 2081, "2081-05-21"},
+	{"Ghatasthapana", 2081, "2081-06-17"},
+	{"Vijaya Dashami", 2080, "2080-07-07"},
+	{"Vijaya Dashami", 2081, "2081-06-26"},
+	{"Vijaya Dashami", 2082, "2082-06-15"},
+	{"Laxmi Puja", 2079, "2079-07-07"},
+	{"Laxmi Puja", 2080, "2080-07-26"},
+	{"Bhai Tika", 2081, "2081-07-18"},
+	{"Chhath", 2081, "2081-07-22"},
+	{"Maha Shivaratri", 2081, "2081-11-14"},
+	{"Fagu Purnima", 2080, "2080-12-11"},
+}
+
+func TestLunarFestivals(t *testing.T) {
+	for _, testCase := range festivalDates {
+		t.Run(testCase.festival, func(t *testing.T) {
+			for _, festival := range LunarFestivals {
+				if festival.Name != testCase.festival {
+					continue
+				}
+				festivalDate, err := festival.DateIn(testCase.year)
+				assert.Equal(t, err, nil)
+				assert.Equal(t, formatForTest(festivalDate), testCase.expected)
+			}
+		})
+	}
+}
+
+func TestResolveAll(t *testing.T) {
+	//Ram Navami falls twice into 2081, once at its start and once at its end
+	var ramNavami = LunarRule{Month: 12, Paksha: Shukla, Tithi: 9}
+	dates, err := ramNavami.ResolveAll(2081)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(dates), 2)
+	assert.Equal(t, formatForTest(dates[0]), "2081-01-05")
+	assert.Equal(t, formatForTest(dates[1]), "2081-12-23")
+
+	first, err := ramNavami.Resolve(2081)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, formatForTest(first), "2081-01-05")
+}
+
+func TestResolveOutOfData(t *testing.T) {
+	_, err := LunarRule{Month: 6, Paksha: Shukla, Tithi: 10}.Resolve(2101)
+	assert.Equal(t, err, ErrInvalidDate)
+}
@@ -0,0 +1,308 @@
+package bsdate
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrDisjointRanges = errors.New("ranges neither overlap nor touch each other")
+
+// Range is a span of consecutive BS days.
+// A closed range includes its end date, a closed-open range stops the day before its end date.
+// A range is empty once its dates are no longer part of the calendar data, e.g. after Reset
+type Range struct {
+	start  Date
+	end    Date
+	closed bool
+}
+
+// NewRange creates a closed-open range, "end" itself is not part of the range.
+// A range with the same start and end is empty. Both dates have to belong to the same calendar
+func NewRange(start, end Date) (Range, error) {
+	if err := checkBoundaries(start, end); err != nil {
+		return Range{}, err
+	}
+	return Range{start: start, end: end}, nil
+}
+
+// NewClosedRange creates a range that includes both "start" and "end"
+func NewClosedRange(start, end Date) (Range, error) {
+	if err := checkBoundaries(start, end); err != nil {
+		return Range{}, err
+	}
+	return Range{start: start, end: end, closed: true}, nil
+}
+
+func checkBoundaries(start, end Date) error {
+	order, err := CalendarOf(start).Compare(start, end)
+	if err != nil {
+		return err
+	}
+	if order > 0 {
+		return errors.New("start of the range has to be before its end")
+	}
+	return nil
+}
+
+// NewRangeFromGregorian creates a closed-open range from gregorian dates, only the date part of start and end is used
+func NewRangeFromGregorian(start, end time.Time) (Range, error) {
+	bsStart, bsEnd, err := gregorianBoundaries(start, end)
+	if err != nil {
+		return Range{}, err
+	}
+	return NewRange(bsStart, bsEnd)
+}
+
+// NewClosedRangeFromGregorian creates a closed range from gregorian dates, only the date part of start and end is used
+func NewClosedRangeFromGregorian(start, end time.Time) (Range, error) {
+	bsStart, bsEnd, err := gregorianBoundaries(start, end)
+	if err != nil {
+		return Range{}, err
+	}
+	return NewClosedRange(bsStart, bsEnd)
+}
+
+func gregorianBoundaries(start, end time.Time) (Date, Date, error) {
+	bsStart, err := NewFromGregorian(start.Day(), int(start.Month()), start.Year())
+	if err != nil {
+		return nil, nil, err
+	}
+	bsEnd, err := NewFromGregorian(end.Day(), int(end.Month()), end.Year())
+	if err != nil {
+		return nil, nil, err
+	}
+	return bsStart, bsEnd, nil
+}
+
+func (r Range) GetStart() Date {
+	return r.start
+}
+
+// GetEnd returns the end date as the range was created with, check IsClosed to know if it is part of the range
+func (r Range) GetEnd() Date {
+	return r.end
+}
+
+func (r Range) IsClosed() bool {
+	return r.closed
+}
+
+// Len returns the amount of days in the range
+func (r Range) Len() int {
+	var first, afterLast = r.ordinals(r.calendar().snapshot())
+	return afterLast - first
+}
+
+func (r Range) IsEmpty() bool {
+	return r.Len() == 0
+}
+
+// Contains reports if d is a day of the range, dates of another calendar are never part of it
+func (r Range) Contains(d Date) bool {
+	if CalendarOf(d) != r.calendar() {
+		return false
+	}
+	var data = r.calendar().snapshot()
+	if !data.contains(d) {
+		return false
+	}
+	var first, afterLast = r.ordinals(data)
+	var day = data.toOrdinal(d)
+	return day >= first && day < afterLast
+}
+
+// Overlaps reports if there is at least one day that is part of both ranges, ranges of different calendars never
+// overlap
+func (r Range) Overlaps(other Range) bool {
+	if r.calendar() != other.calendar() {
+		return false
+	}
+	var data = r.calendar().snapshot()
+	var first, afterLast = r.ordinals(data)
+	var otherFirst, otherAfterLast = other.ordinals(data)
+	return first < otherAfterLast && otherFirst < afterLast
+}
+
+// Intersect returns the closed range of days that are part of both ranges
+// the second return value is false if the ranges do not overlap
+func (r Range) Intersect(other Range) (Range, bool) {
+	if r.calendar() != other.calendar() {
+		return Range{}, false
+	}
+	var data = r.calendar().snapshot()
+	var first, afterLast = r.ordinals(data)
+	var otherFirst, otherAfterLast = other.ordinals(data)
+	if otherFirst > first {
+		first = otherFirst
+	}
+	if otherAfterLast < afterLast {
+		afterLast = otherAfterLast
+	}
+	if first >= afterLast {
+		return Range{}, false
+	}
+	result, err := r.calendar().closedRangeFromOrdinals(data, first, afterLast-1)
+	if err != nil {
+		return Range{}, false
+	}
+	return result, true
+}
+
+// Union returns the closed range covering both ranges
+// the ranges have to overlap or follow each other directly, else ErrDisjointRanges is returned.
+// ErrMixedCalendars is returned for ranges of different calendars
+func (r Range) Union(other Range) (Range, error) {
+	if r.calendar() != other.calendar() {
+		return Range{}, ErrMixedCalendars
+	}
+	var data = r.calendar().snapshot()
+	var first, afterLast = r.ordinals(data)
+	var otherFirst, otherAfterLast = other.ordinals(data)
+	if first > otherAfterLast || otherFirst > afterLast {
+		return Range{}, ErrDisjointRanges
+	}
+	if otherFirst < first {
+		first = otherFirst
+	}
+	if otherAfterLast > afterLast {
+		afterLast = otherAfterLast
+	}
+	if first == afterLast {
+		return Range{start: r.start, end: r.start}, nil
+	}
+	return r.calendar().closedRangeFromOrdinals(data, first, afterLast-1)
+}
+
+// SplitByMonth cuts the range at every BS month boundary, the parts are returned as closed ranges
+func (r Range) SplitByMonth() []Range {
+	return r.split(func(d Date) bool {
+		return d.GetDay() == 1
+	})
+}
+
+// SplitByFiscalYear cuts the range at every start of a fiscal year (1st Shrawan), the parts are returned as closed ranges
+func (r Range) SplitByFiscalYear() []Range {
+	return r.split(func(d Date) bool {
+		return d.GetDay() == 1 && d.GetMonth() == FiscalYearStartMonth
+	})
+}
+
+// split starts a new part at every day in the range for which isBoundary returns true
+func (r Range) split(isBoundary func(Date) bool) []Range {
+	var parts []Range
+	var partStart, previous Date
+	for iterator := r.Iterate(); iterator.Next(); {
+		var current = iterator.Date()
+		if partStart != nil && isBoundary(current) {
+			parts = append(parts, Range{start: partStart, end: previous, closed: true})
+			partStart = nil
+		}
+		if partStart == nil {
+			partStart = current
+		}
+		previous = current
+	}
+	if partStart != nil {
+		parts = append(parts, Range{start: partStart, end: previous, closed: true})
+	}
+	return parts
+}
+
+// Gregorian returns the gregorian dates of the start and the end of the range
+// for closed-open ranges end is, like in the BS range, the first day after the range
+func (r Range) Gregorian() (start time.Time, end time.Time, err error) {
+	start, err = r.start.GetGregorianDate()
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err = r.end.GetGregorianDate()
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return start, end, nil
+}
+
+// ordinals returns the ordinal of the first day in the range and of the first day after the range
+func (r Range) ordinals(data *calendarSnapshot) (int, int) {
+	if r.start == nil || r.end == nil || !data.contains(r.start) || !data.contains(r.end) {
+		return 0, 0
+	}
+	var first, afterLast = data.toOrdinal(r.start), data.toOrdinal(r.end)
+	if r.closed {
+		afterLast++
+	}
+	return first, afterLast
+}
+
+// calendar returns the calendar of the dates of the range, empty ranges belong to the default calendar
+func (r Range) calendar() *Calendar {
+	if r.start == nil {
+		return defaultCalendar
+	}
+	return CalendarOf(r.start)
+}
+
+func (c *Calendar) closedRangeFromOrdinals(data *calendarSnapshot, first, last int) (Range, error) {
+	start, err := c.fromOrdinal(data, first)
+	if err != nil {
+		return Range{}, err
+	}
+	end, err := c.fromOrdinal(data, last)
+	if err != nil {
+		return Range{}, err
+	}
+	return Range{start: start, end: end, closed: true}, nil
+}
+
+// Iterate returns an iterator over all days in the range
+//
+//	for iterator := r.Iterate(); iterator.Next(); {
+//		day := iterator.Date()
+//	}
+func (r Range) Iterate() *RangeIterator {
+	return &RangeIterator{remaining: r.Len(), next: r.start}
+}
+
+// RangeIterator walks day by day through a Range
+type RangeIterator struct {
+	remaining int
+	next      Date
+	current   Date
+}
+
+// Next advances to the next day, it returns false once all days of the range were visited
+func (it *RangeIterator) Next() bool {
+	if it.remaining <= 0 || it.next == nil {
+		return false
+	}
+	it.current = it.next
+	it.remaining--
+	if it.remaining > 0 {
+		it.next = dayAfter(it.current)
+	}
+	return true
+}
+
+// Date returns the day the iterator is currently at
+func (it *RangeIterator) Date() Date {
+	return it.current
+}
+
+// dayAfter returns the next day or nil if there is no data for it
+func dayAfter(d Date) Date {
+	var calendar = CalendarOf(d)
+	var day, month, year = d.GetDay() + 1, d.GetMonth(), d.GetYear()
+	if days, _ := calendar.DaysInMonth(year, month); day > days {
+		day = 1
+		month++
+	}
+	if month > 12 {
+		month = 1
+		year++
+	}
+	next, err := calendar.New(day, month, year)
+	if err != nil {
+		return nil
+	}
+	return next
+}
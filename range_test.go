@@ -0,0 +1,227 @@
+package bsdate
+
+import (
+	"fmt"
+	"github.com/magiconair/properties/assert"
+	"testing"
+	"time"
+)
+
+func mustNew(t *testing.T, dateString string) Date {
+	return mustNewIn(t, defaultCalendar, dateString)
+}
+
+// mustNewIn creates a date of the given calendar
+func mustNewIn(t *testing.T, c *Calendar, dateString string) Date {
+	var year, month, day = splitDateString(dateString)
+	d, err := c.New(day, month, year)
+	if err != nil {
+		t.Fatalf("cannot create %s: %v", dateString, err)
+	}
+	return d
+}
+
+func formatForTest(d Date) string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.GetYear(), d.GetMonth(), d.GetDay())
+}
+
+type TestRangeStruc struct {
+	start  string
+	end    string
+	closed bool
+}
+
+func (s TestRangeStruc) create(t *testing.T) Range {
+	var r Range
+	var err error
+	if s.closed {
+		r, err = NewClosedRange(mustNew(t, s.start), mustNew(t, s.end))
+	} else {
+		r, err = NewRange(mustNew(t, s.start), mustNew(t, s.end))
+	}
+	assert.Equal(t, err, nil)
+	return r
+}
+
+var rangeLengths = []struct {
+	r           TestRangeStruc
+	expectedLen int
+}{
+	{TestRangeStruc{"2081-07-10", "2081-08-05", false}, 25}, //Kartik has 30 days in 2081
+	{TestRangeStruc{"2081-07-10", "2081-08-05", true}, 26},
+	{TestRangeStruc{"2081-07-10", "2081-07-10", false}, 0},
+	{TestRangeStruc{"2081-07-10", "2081-07-10", true}, 1},
+	{TestRangeStruc{"2080-12-30", "2081-01-01", true}, 2},
+	{TestRangeStruc{"2081-01-01", "2082-01-01", false}, 366},
+}
+
+func TestRangeLen(t *testing.T) {
+	for _, testCase := range rangeLengths {
+		t.Run(testCase.r.start+"/"+testCase.r.end, func(t *testing.T) {
+			var r = testCase.r.create(t)
+			assert.Equal(t, r.Len(), testCase.expectedLen)
+
+			var visited = 0
+			var last Date
+			for iterator := r.Iterate(); iterator.Next(); {
+				if last != nil {
+					days, err := DaysBetween(last, iterator.Date())
+					assert.Equal(t, err, nil)
+					assert.Equal(t, days, 1)
+				}
+				last = iterator.Date()
+				visited++
+			}
+			assert.Equal(t, visited, testCase.expectedLen)
+		})
+	}
+}
+
+func TestRangeStartAfterEnd(t *testing.T) {
+	_, err := NewRange(mustNew(t, "2081-07-10"), mustNew(t, "2081-07-09"))
+	assert.Equal(t, err.Error(), "start of the range has to be before its end")
+	_, err = NewClosedRange(mustNew(t, "2081-07-10"), mustNew(t, "2081-07-09"))
+	assert.Equal(t, err.Error(), "start of the range has to be before its end")
+}
+
+func TestRangeContains(t *testing.T) {
+	var r = TestRangeStruc{"2081-07-10", "2081-08-05", false}.create(t)
+	assert.Equal(t, r.Contains(mustNew(t, "2081-07-10")), true)
+	assert.Equal(t, r.Contains(mustNew(t, "2081-08-04")), true)
+	assert.Equal(t, r.Contains(mustNew(t, "2081-08-05")), false)
+	assert.Equal(t, r.Contains(mustNew(t, "2081-07-09")), false)
+
+	r = TestRangeStruc{"2081-07-10", "2081-08-05", true}.create(t)
+	assert.Equal(t, r.Contains(mustNew(t, "2081-08-05")), true)
+}
+
+var rangeIntersections = []struct {
+	a, b          TestRangeStruc
+	overlaps      bool
+	intersection  string
+	union         string
+	unionErrorMsg string
+}{
+	{
+		TestRangeStruc{"2081-07-10", "2081-08-05", false},
+		TestRangeStruc{"2081-08-01", "2081-09-01", true},
+		true, "2081-08-01/2081-08-04", "2081-07-10/2081-09-01", "",
+	},
+	{
+		//closed-open ranges that follow each other directly do not overlap, but can be joined
+		TestRangeStruc{"2081-07-10", "2081-08-05", false},
+		TestRangeStruc{"2081-08-05", "2081-09-01", false},
+		false, "", "2081-07-10/2081-08-30", "",
+	},
+	{
+		TestRangeStruc{"2081-07-10", "2081-08-05", true},
+		TestRangeStruc{"2081-08-05", "2081-09-01", true},
+		true, "2081-08-05/2081-08-05", "2081-07-10/2081-09-01", "",
+	},
+	{
+		TestRangeStruc{"2081-01-01", "2081-12-30", true},
+		TestRangeStruc{"2081-03-15", "2081-03-20", true},
+		true, "2081-03-15/2081-03-20", "2081-01-01/2081-12-30", "",
+	},
+	{
+		TestRangeStruc{"2081-07-10", "2081-08-05", true},
+		TestRangeStruc{"2081-08-07", "2081-09-01", true},
+		false, "", "", "ranges neither overlap nor touch each other",
+	},
+}
+
+func TestRangeIntersectAndUnion(t *testing.T) {
+	for _, testCase := range rangeIntersections {
+		t.Run(testCase.a.start+"/"+testCase.b.start, func(t *testing.T) {
+			var a, b = testCase.a.create(t), testCase.b.create(t)
+			assert.Equal(t, a.Overlaps(b), testCase.overlaps)
+			assert.Equal(t, b.Overlaps(a), testCase.overlaps)
+
+			intersection, ok := a.Intersect(b)
+			assert.Equal(t, ok, testCase.overlaps)
+			if ok {
+				assert.Equal(t, intersection.IsClosed(), true)
+				assert.Equal(t, formatForTest(intersection.GetStart())+"/"+formatForTest(intersection.GetEnd()), testCase.intersection)
+			}
+
+			union, err := a.Union(b)
+			if testCase.unionErrorMsg != "" {
+				assert.Equal(t, err.Error(), testCase.unionErrorMsg)
+				return
+			}
+			assert.Equal(t, err, nil)
+			assert.Equal(t, formatForTest(union.GetStart())+"/"+formatForTest(union.GetEnd()), testCase.union)
+		})
+	}
+}
+
+func TestRangeSplitByMonth(t *testing.T) {
+	var r = TestRangeStruc{"2081-07-10", "2081-09-05", false}.create(t)
+	var parts = r.SplitByMonth()
+	var got []string
+	for _, part := range parts {
+		got = append(got, formatForTest(part.GetStart())+"/"+formatForTest(part.GetEnd()))
+	}
+	assert.Equal(t, got, []string{"2081-07-10/2081-07-30", "2081-08-01/2081-08-30", "2081-09-01/2081-09-04"})
+}
+
+func TestRangeSplitByFiscalYear(t *testing.T) {
+	var r = TestRangeStruc{"2080-02-15", "2082-04-01", true}.create(t)
+	var parts = r.SplitByFiscalYear()
+	var got []string
+	for _, part := range parts {
+		got = append(got, formatForTest(part.GetStart())+"/"+formatForTest(part.GetEnd()))
+	}
+	assert.Equal(t, got, []string{"2080-02-15/2080-03-31", "2080-04-01/2081-03-32", "2081-04-01/2082-03-31", "2082-04-01/2082-04-01"})
+}
+
+func TestRangeSplitEmpty(t *testing.T) {
+	var r = TestRangeStruc{"2081-07-10", "2081-07-10", false}.create(t)
+	assert.Equal(t, len(r.SplitByMonth()), 0)
+}
+
+func TestRangeGregorian(t *testing.T) {
+	var start, _ = time.Parse("2006-01-02", "2024-10-26")
+	var end, _ = time.Parse("2006-01-02", "2024-11-20")
+	r, err := NewClosedRangeFromGregorian(start, end)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, formatForTest(r.GetStart()), "2081-07-10")
+	assert.Equal(t, formatForTest(r.GetEnd()), "2081-08-05")
+	assert.Equal(t, r.Len(), 26)
+
+	gregorianStart, gregorianEnd, err := r.Gregorian()
+	assert.Equal(t, err, nil)
+	assert.Equal(t, gregorianStart, start)
+	assert.Equal(t, gregorianEnd, end)
+
+	r, err = NewRangeFromGregorian(start, end)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, r.Len(), 25)
+}
+
+func TestRangeOfVendorCalendar(t *testing.T) {
+	var vendor = vendorCalendar(t)
+	a, err := NewClosedRange(mustNewIn(t, vendor, "2081-01-25"), mustNewIn(t, vendor, "2081-02-10"))
+	assert.Equal(t, err, nil)
+	b, err := NewClosedRange(mustNewIn(t, vendor, "2081-02-01"), mustNewIn(t, vendor, "2081-02-20"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, a.Len(), 16) //Baisakh 2081 has 30 days in the vendor calendar
+
+	intersection, ok := a.Intersect(b)
+	assert.Equal(t, ok, true)
+	assert.Equal(t, formatForTest(intersection.GetStart())+"/"+formatForTest(intersection.GetEnd()), "2081-02-01/2081-02-10")
+	assert.Equal(t, CalendarOf(intersection.GetEnd()), vendor)
+	union, err := a.Union(b)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, formatForTest(union.GetStart())+"/"+formatForTest(union.GetEnd()), "2081-01-25/2081-02-20")
+	assert.Equal(t, CalendarOf(union.GetStart()), vendor)
+
+	official, err := NewClosedRange(mustNew(t, "2081-01-25"), mustNew(t, "2081-02-10"))
+	assert.Equal(t, err, nil)
+	assert.Equal(t, a.Overlaps(official), false)
+	assert.Equal(t, a.Contains(mustNew(t, "2081-02-01")), false)
+	_, err = a.Union(official)
+	assert.Equal(t, err, ErrMixedCalendars)
+	_, err = NewRange(mustNew(t, "2081-01-25"), mustNewIn(t, vendor, "2081-02-10"))
+	assert.Equal(t, err, ErrMixedCalendars)
+}
@@ -0,0 +1,514 @@
+package bsdate
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the period a recurrence rule repeats in
+type Frequency int
+
+const (
+	Daily Frequency = iota
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencyNames = map[Frequency]string{Daily: "DAILY", Weekly: "WEEKLY", Monthly: "MONTHLY", Yearly: "YEARLY"}
+
+// MissingDayPolicy decides what happens if a rule asks for a day the month does not have, e.g. the 32nd of a 30 day month
+type MissingDayPolicy int
+
+const (
+	// SkipMissingDay leaves the month out
+	SkipMissingDay MissingDayPolicy = iota
+	// ClampToLastDay uses the last day of the month instead
+	ClampToLastDay
+)
+
+var missingDayPolicyNames = map[MissingDayPolicy]string{SkipMissingDay: "SKIP", ClampToLastDay: "CLAMP"}
+
+var weekdayNames = [7]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Rule describes repeating BS dates, modeled after the RRULE of RFC 5545 but counting in BS months and years.
+//
+//	every month on the 15th:             Rule{Freq: Monthly, ByMonthDay: []int{15}}
+//	the last day of every month:         Rule{Freq: Monthly, ByMonthDay: []int{-1}}
+//	every 1st Baisakh:                   Rule{Freq: Yearly, ByMonth: []int{1}, ByMonthDay: []int{1}}
+//	the 25th after every fiscal quarter: Rule{Freq: Yearly, ByMonth: []int{1, 4, 7, 10}, ByMonthDay: []int{25}}
+//	the last Friday of every month:      Rule{Freq: Monthly, ByWeekday: []time.Weekday{time.Friday}, BySetPos: []int{-1}}
+type Rule struct {
+	Freq Frequency
+	// Interval is the amount of periods between occurrences, 0 is treated as 1
+	Interval int
+	// ByMonth limits the occurrences to these months, for yearly rules it selects the months
+	ByMonth []int
+	// ByMonthDay selects days of the month, negative values count from the end of the month (-1 is the last day)
+	ByMonthDay []int
+	// ByWeekday selects (or for daily rules limits to) days of the week, weeks start on Sunday
+	ByWeekday []time.Weekday
+	// BySetPos picks occurrences by their position in a period, negative values count from the end
+	BySetPos []int
+	// Count stops the recurrence after this many occurrences, 0 means no limit
+	Count int
+	// Until is the last day an occurrence can fall on, nil means no limit
+	Until Date
+	// MissingDay decides about days that do not exist in a month
+	MissingDay MissingDayPolicy
+}
+
+func (r Rule) validate() error {
+	if _, ok := frequencyNames[r.Freq]; !ok {
+		return errors.New("unknown frequency " + strconv.Itoa(int(r.Freq)))
+	}
+	if _, ok := missingDayPolicyNames[r.MissingDay]; !ok {
+		return errors.New("unknown missing day policy " + strconv.Itoa(int(r.MissingDay)))
+	}
+	if r.Interval < 0 {
+		return errors.New("interval cannot be negative")
+	}
+	if r.Count < 0 {
+		return errors.New("count cannot be negative")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return errors.New("count and until cannot be used together")
+	}
+	for _, month := range r.ByMonth {
+		if month < 1 || month > 12 {
+			return errors.New("month " + strconv.Itoa(month) + " is not between 1 and 12")
+		}
+	}
+	for _, day := range r.ByMonthDay {
+		if day == 0 || day < -32 || day > 32 {
+			return errors.New("day of month " + strconv.Itoa(day) + " is not between 1 and 32 or -32 and -1")
+		}
+	}
+	for _, weekday := range r.ByWeekday {
+		if weekday < time.Sunday || weekday > time.Saturday {
+			return errors.New("invalid weekday " + strconv.Itoa(int(weekday)))
+		}
+	}
+	for _, position := range r.BySetPos {
+		if position == 0 || position < -366 || position > 366 {
+			return errors.New("set position " + strconv.Itoa(position) + " is not between 1 and 366 or -366 and -1")
+		}
+	}
+	return nil
+}
+
+// String returns the rule in the form ParseRule reads, e.g. "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=12"
+func (r Rule) String() string {
+	var parts = []string{"FREQ=" + frequencyNames[r.Freq]}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByMonth) > 0 {
+		parts = append(parts, "BYMONTH="+joinInts(r.ByMonth))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.ByWeekday) > 0 {
+		var names []string
+		for _, weekday := range r.ByWeekday {
+			names = append(names, weekdayNames[weekday])
+		}
+		parts = append(parts, "BYDAY="+strings.Join(names, ","))
+	}
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(r.BySetPos))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+Format(r.Until, ISOLayout))
+	}
+	if r.MissingDay != SkipMissingDay {
+		parts = append(parts, "MISSINGDAY="+missingDayPolicyNames[r.MissingDay])
+	}
+	return strings.Join(parts, ";")
+}
+
+// ParseRule reads a rule written like "FREQ=YEARLY;BYMONTH=1;BYMONTHDAY=1".
+// UNTIL takes a BS date as YYYY-MM-DD and MISSINGDAY is either SKIP or CLAMP
+func ParseRule(s string) (Rule, error) {
+	var r Rule
+	var hasFrequency = false
+	for _, part := range strings.Split(strings.TrimPrefix(strings.TrimSpace(s), "RRULE:"), ";") {
+		var keyValue = strings.SplitN(part, "=", 2)
+		if len(keyValue) != 2 {
+			return Rule{}, errors.New("invalid rule part " + part)
+		}
+		var key, value = strings.ToUpper(keyValue[0]), strings.ToUpper(keyValue[1])
+		var err error
+		switch key {
+		case "FREQ":
+			hasFrequency = false
+			for frequency, name := range frequencyNames {
+				if name == value {
+					r.Freq, hasFrequency = frequency, true
+				}
+			}
+			if !hasFrequency {
+				return Rule{}, errors.New("unknown frequency " + value)
+			}
+		case "INTERVAL":
+			r.Interval, err = strconv.Atoi(value)
+		case "BYMONTH":
+			r.ByMonth, err = splitInts(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = splitInts(value)
+		case "BYDAY":
+			r.ByWeekday, err = splitWeekdays(value)
+		case "BYSETPOS":
+			r.BySetPos, err = splitInts(value)
+		case "COUNT":
+			r.Count, err = strconv.Atoi(value)
+		case "UNTIL":
+			r.Until, err = Parse(ISOLayout, value)
+		case "MISSINGDAY":
+			var known = false
+			for policy, name := range missingDayPolicyNames {
+				if name == value {
+					r.MissingDay, known = policy, true
+				}
+			}
+			if !known {
+				return Rule{}, errors.New("unknown missing day policy " + value)
+			}
+		default:
+			return Rule{}, errors.New("unknown rule part " + key)
+		}
+		if err != nil {
+			return Rule{}, errors.New("invalid value for " + key + ": " + value)
+		}
+	}
+	if !hasFrequency {
+		return Rule{}, errors.New("rule has no FREQ")
+	}
+	return r, r.validate()
+}
+
+func joinInts(values []int) string {
+	var parts = make([]string, len(values))
+	for i, value := range values {
+		parts[i] = strconv.Itoa(value)
+	}
+	return strings.Join(parts, ",")
+}
+
+func splitInts(s string) ([]int, error) {
+	var values []int
+	for _, part := range strings.Split(s, ",") {
+		value, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func splitWeekdays(s string) ([]time.Weekday, error) {
+	var weekdays []time.Weekday
+	for _, part := range strings.Split(s, ",") {
+		var found = false
+		for weekday, name := range weekdayNames {
+			if name == strings.TrimSpace(part) {
+				weekdays = append(weekdays, time.Weekday(weekday))
+				found = true
+			}
+		}
+		if !found {
+			return nil, errors.New("unknown weekday " + part)
+		}
+	}
+	return weekdays, nil
+}
+
+// Iterate returns an iterator over the occurrences of the rule starting at "start".
+// Like DTSTART in RFC 5545, "start" anchors the periods and supplies the day, weekday or month the rule does not set,
+// no occurrence is before it. The occurrences belong to the calendar of "start", Until has to belong to it as well,
+// otherwise ErrMixedCalendars is returned. The occurrences are calculated one period at a time while iterating
+//
+//	iterator, err := rule.Iterate(start)
+//	for iterator.Next() {
+//		day := iterator.Date()
+//	}
+func (r Rule) Iterate(start Date) (*RuleIterator, error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	if start == nil {
+		return nil, errors.New("recurrence needs a start date")
+	}
+	var calendar = CalendarOf(start)
+	if r.Until != nil && CalendarOf(r.Until) != calendar {
+		return nil, ErrMixedCalendars
+	}
+	if r.Interval == 0 {
+		r.Interval = 1
+	}
+	var data = calendar.snapshot()
+	if !data.contains(start) || (r.Until != nil && !data.contains(r.Until)) {
+		return nil, ErrMissingData
+	}
+	return &RuleIterator{
+		rule:         r,
+		calendar:     calendar,
+		data:         data,
+		start:        start,
+		startOrdinal: data.toOrdinal(start),
+		startWeekday: int(Weekday(start)),
+	}, nil
+}
+
+// Expand returns the first "limit" occurrences of the rule starting at "start"
+func (r Rule) Expand(start Date, limit int) ([]Date, error) {
+	iterator, err := r.Iterate(start)
+	if err != nil {
+		return nil, err
+	}
+	var occurrences []Date
+	for len(occurrences) < limit && iterator.Next() {
+		occurrences = append(occurrences, iterator.Date())
+	}
+	return occurrences, nil
+}
+
+// RuleIterator walks through the occurrences of a Rule, it stops at Count, Until or the end of the calendar data
+type RuleIterator struct {
+	rule     Rule
+	calendar *Calendar
+	//data is the calendar data when the iteration started, all occurrences are taken from it
+	data         *calendarSnapshot
+	start        Date
+	startOrdinal int
+	startWeekday int
+	period       int
+	pending      []Date
+	emitted      int
+	current      Date
+	done         bool
+}
+
+// Next advances to the next occurrence, it returns false once there are no more
+func (it *RuleIterator) Next() bool {
+	for !it.done {
+		if len(it.pending) == 0 {
+			if !it.expandPeriod() {
+				it.done = true
+			}
+			continue
+		}
+		var next = it.pending[0]
+		it.pending = it.pending[1:]
+		if it.rule.Until != nil && it.data.toOrdinal(next) > it.data.toOrdinal(it.rule.Until) {
+			it.done = true
+			return false
+		}
+		it.current = next
+		it.emitted++
+		it.done = it.rule.Count > 0 && it.emitted >= it.rule.Count
+		return true
+	}
+	return false
+}
+
+// Date returns the occurrence the iterator is currently at
+func (it *RuleIterator) Date() Date {
+	return it.current
+}
+
+// expandPeriod fills pending with the occurrences of the next period,
+// it returns false if the period is outside of the calendar data
+func (it *RuleIterator) expandPeriod() bool {
+	var r = it.rule
+	var candidates []Date
+	switch r.Freq {
+	case Daily:
+		day, err := it.calendar.fromOrdinal(it.data, it.startOrdinal+it.period*r.Interval)
+		if err != nil {
+			return false
+		}
+		if it.matchesMonth(day) && it.matchesMonthDay(day) && it.matchesWeekday(day) {
+			candidates = append(candidates, day)
+		}
+	case Weekly:
+		var weekStart = it.startOrdinal - it.startWeekday + it.period*7*r.Interval
+		if _, err := it.calendar.fromOrdinal(it.data, weekStart+6); err != nil {
+			return false
+		}
+		for ordinal := weekStart; ordinal < weekStart+7; ordinal++ {
+			day, err := it.calendar.fromOrdinal(it.data, ordinal)
+			if err != nil {
+				continue
+			}
+			if len(r.ByWeekday) == 0 && ordinal-weekStart != it.startWeekday {
+				continue
+			}
+			if it.matchesMonth(day) && it.matchesMonthDay(day) && it.matchesWeekday(day) {
+				candidates = append(candidates, day)
+			}
+		}
+	case Monthly:
+		var monthIndex = it.start.GetYear()*12 + it.start.GetMonth() - 1 + it.period*r.Interval
+		var year, month = monthIndex / 12, monthIndex%12 + 1
+		if _, err := it.data.daysInMonth(year, month); err != nil {
+			return false
+		}
+		if containsInt(r.ByMonth, month) || len(r.ByMonth) == 0 {
+			candidates = it.monthCandidates(year, month)
+		}
+	case Yearly:
+		var year = it.start.GetYear() + it.period*r.Interval
+		if _, err := it.data.daysInMonth(year, 1); err != nil {
+			return false
+		}
+		var months = r.ByMonth
+		if len(months) == 0 {
+			months = []int{it.start.GetMonth()}
+			if len(r.ByMonthDay) > 0 || len(r.ByWeekday) > 0 {
+				months = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+			}
+		}
+		for _, month := range months {
+			candidates = append(candidates, it.monthCandidates(year, month)...)
+		}
+	}
+	it.period++
+
+	it.sortDates(candidates)
+	candidates = it.uniqueDates(candidates)
+	if len(r.BySetPos) > 0 {
+		var selected []Date
+		for _, position := range r.BySetPos {
+			var index = position - 1
+			if position < 0 {
+				index = len(candidates) + position
+			}
+			if index >= 0 && index < len(candidates) {
+				selected = append(selected, candidates[index])
+			}
+		}
+		it.sortDates(selected)
+		candidates = it.uniqueDates(selected)
+	}
+	it.pending = it.pending[:0]
+	for _, candidate := range candidates {
+		if it.data.toOrdinal(candidate) >= it.startOrdinal {
+			it.pending = append(it.pending, candidate)
+		}
+	}
+	return true
+}
+
+// monthCandidates returns all days of the month selected by ByMonthDay and ByWeekday
+func (it *RuleIterator) monthCandidates(year, month int) []Date {
+	var daysInMonth, err = it.data.daysInMonth(year, month)
+	if err != nil {
+		return nil
+	}
+	var days []int
+	switch {
+	case len(it.rule.ByMonthDay) > 0:
+		days = it.monthDays(daysInMonth)
+	case len(it.rule.ByWeekday) > 0:
+		for day := 1; day <= daysInMonth; day++ {
+			days = append(days, day)
+		}
+	default:
+		days = it.resolveDays([]int{it.start.GetDay()}, daysInMonth)
+	}
+	var candidates []Date
+	for _, day := range days {
+		if d := it.calendar.date(day, month, year); it.matchesWeekday(d) {
+			candidates = append(candidates, d)
+		}
+	}
+	return candidates
+}
+
+// monthDays resolves ByMonthDay for a month with the given length
+func (it *RuleIterator) monthDays(daysInMonth int) []int {
+	return it.resolveDays(it.rule.ByMonthDay, daysInMonth)
+}
+
+// resolveDays turns negative days into days from the start of the month and applies the missing day policy
+func (it *RuleIterator) resolveDays(days []int, daysInMonth int) []int {
+	var resolved []int
+	for _, day := range days {
+		if day < 0 {
+			day = daysInMonth + 1 + day
+			if day < 1 {
+				continue
+			}
+		}
+		if day > daysInMonth {
+			if it.rule.MissingDay != ClampToLastDay {
+				continue
+			}
+			day = daysInMonth
+		}
+		resolved = append(resolved, day)
+	}
+	return resolved
+}
+
+func (it *RuleIterator) matchesMonth(d Date) bool {
+	return len(it.rule.ByMonth) == 0 || containsInt(it.rule.ByMonth, d.GetMonth())
+}
+
+func (it *RuleIterator) matchesMonthDay(d Date) bool {
+	if len(it.rule.ByMonthDay) == 0 {
+		return true
+	}
+	daysInMonth, err := it.data.daysInMonth(d.GetYear(), d.GetMonth())
+	return err == nil && containsInt(it.monthDays(daysInMonth), d.GetDay())
+}
+
+func (it *RuleIterator) matchesWeekday(d Date) bool {
+	if len(it.rule.ByWeekday) == 0 {
+		return true
+	}
+	//counting from the weekday of the start avoids converting every day to gregorian
+	var weekday = ((it.startWeekday+it.data.toOrdinal(d)-it.startOrdinal)%7 + 7) % 7
+	for _, wanted := range it.rule.ByWeekday {
+		if int(wanted) == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// sortDates sorts dates of the calendar of the iterator
+func (it *RuleIterator) sortDates(dates []Date) {
+	sort.Slice(dates, func(i, j int) bool {
+		return it.data.toOrdinal(dates[i]) < it.data.toOrdinal(dates[j])
+	})
+}
+
+// uniqueDates removes duplicates from sorted dates of the calendar of the iterator
+func (it *RuleIterator) uniqueDates(dates []Date) []Date {
+	var unique []Date
+	for _, d := range dates {
+		if len(unique) == 0 || it.data.toOrdinal(unique[len(unique)-1]) != it.data.toOrdinal(d) {
+			unique = append(unique, d)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,154 @@
+package bsdate
+
+import (
+	"github.com/magiconair/properties/assert"
+	"testing"
+	"time"
+)
+
+func TestRuleExpand(t *testing.T) {
+	var tests = []struct {
+		name     string
+		rule     Rule
+		start    string
+		expected []string
+	}{
+		{"every month on the 15th", Rule{Freq: Monthly, ByMonthDay: []int{15}}, "2081-01-01",
+			[]string{"2081-01-15", "2081-02-15", "2081-03-15"}},
+		{"last day of every month", Rule{Freq: Monthly, ByMonthDay: []int{-1}}, "2081-01-01",
+			[]string{"2081-01-31", "2081-02-31", "2081-03-32", "2081-04-32"}},
+		{"every 1st Baisakh", Rule{Freq: Yearly, ByMonth: []int{1}, ByMonthDay: []int{1}}, "2080-06-10",
+			[]string{"2081-01-01", "2082-01-01", "2083-01-01"}},
+		{"25th after every quarter", Rule{Freq: Yearly, ByMonth: []int{1, 4, 7, 10}, ByMonthDay: []int{25}}, "2081-03-01",
+			[]string{"2081-04-25", "2081-07-25", "2081-10-25", "2082-01-25"}},
+		{"day of the start", Rule{Freq: Monthly, Interval: 2}, "2081-01-20",
+			[]string{"2081-01-20", "2081-03-20", "2081-05-20"}},
+		{"skip missing day", Rule{Freq: Monthly, ByMonthDay: []int{32}}, "2081-01-01",
+			[]string{"2081-03-32", "2081-04-32", "2082-02-32"}},
+		{"clamp missing day", Rule{Freq: Monthly, ByMonthDay: []int{32}, MissingDay: ClampToLastDay}, "2081-01-01",
+			[]string{"2081-01-31", "2081-02-31", "2081-03-32"}},
+		{"last friday of the month", Rule{Freq: Monthly, ByWeekday: []time.Weekday{time.Friday}, BySetPos: []int{-1}}, "2081-01-01",
+			[]string{"2081-01-28", "2081-02-25"}},
+		{"every second week on sunday and wednesday", Rule{Freq: Weekly, Interval: 2, ByWeekday: []time.Weekday{time.Sunday, time.Wednesday}}, "2081-01-01",
+			[]string{"2081-01-09", "2081-01-12", "2081-01-23"}},
+		{"weekly on the weekday of the start", Rule{Freq: Weekly}, "2081-01-01",
+			[]string{"2081-01-01", "2081-01-08", "2081-01-15"}},
+		{"every 10 days until", Rule{Freq: Daily, Interval: 10, Until: mustNew(t, "2081-01-25")}, "2081-01-01",
+			[]string{"2081-01-01", "2081-01-11", "2081-01-21"}},
+		{"count", Rule{Freq: Daily, ByMonthDay: []int{1, 2}, Count: 3}, "2081-01-01",
+			[]string{"2081-01-01", "2081-01-02", "2081-02-01"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			//the limit is larger than needed to check that count and until stop the recurrence
+			var limit = len(test.expected)
+			if test.rule.Count > 0 || test.rule.Until != nil {
+				limit = 100
+			}
+			occurrences, err := test.rule.Expand(mustNew(t, test.start), limit)
+			assert.Equal(t, err, nil)
+			var actual []string
+			for _, occurrence := range occurrences {
+				actual = append(actual, formatForTest(occurrence))
+			}
+			assert.Equal(t, actual, test.expected)
+		})
+	}
+}
+
+func TestRuleStopsAtEndOfData(t *testing.T) {
+	iterator, err := Rule{Freq: Yearly}.Iterate(mustNew(t, "2099-05-05"))
+	assert.Equal(t, err, nil)
+	var occurrences []string
+	for iterator.Next() {
+		occurrences = append(occurrences, formatForTest(iterator.Date()))
+	}
+	assert.Equal(t, occurrences, []string{"2099-05-05", "2100-05-05"})
+	assert.Equal(t, iterator.Next(), false)
+}
+
+func TestRuleIterateInvalid(t *testing.T) {
+	var tests = []Rule{
+		{Freq: Frequency(7)},
+		{Freq: Monthly, Interval: -1},
+		{Freq: Monthly, ByMonth: []int{13}},
+		{Freq: Monthly, ByMonthDay: []int{0}},
+		{Freq: Monthly, BySetPos: []int{0}},
+		{Freq: Monthly, Count: 2, Until: mustNew(t, "2081-01-01")},
+	}
+	for _, test := range tests {
+		_, err := test.Iterate(mustNew(t, "2081-01-01"))
+		assert.Equal(t, err != nil, true)
+	}
+}
+
+func TestRuleString(t *testing.T) {
+	var tests = []struct {
+		rule Rule
+		text string
+	}{
+		{Rule{Freq: Monthly, ByMonthDay: []int{-1}, Count: 12}, "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=12"},
+		{Rule{Freq: Yearly, Interval: 2, ByMonth: []int{1, 4}, ByMonthDay: []int{25}, Until: mustNew(t, "2090-12-30")},
+			"FREQ=YEARLY;INTERVAL=2;BYMONTH=1,4;BYMONTHDAY=25;UNTIL=2090-12-30"},
+		{Rule{Freq: Monthly, ByWeekday: []time.Weekday{time.Friday, time.Saturday}, BySetPos: []int{-1}, MissingDay: ClampToLastDay},
+			"FREQ=MONTHLY;BYDAY=FR,SA;BYSETPOS=-1;MISSINGDAY=CLAMP"},
+	}
+	for _, test := range tests {
+		t.Run(test.text, func(t *testing.T) {
+			assert.Equal(t, test.rule.String(), test.text)
+			parsed, err := ParseRule(test.text)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, parsed.String(), test.text)
+		})
+	}
+}
+
+func TestParseRuleInvalid(t *testing.T) {
+	var tests = []string{
+		"",
+		"BYMONTHDAY=1",
+		"FREQ=HOURLY",
+		"FREQ=MONTHLY;BYMONTHDAY=x",
+		"FREQ=MONTHLY;BYDAY=XX",
+		"FREQ=MONTHLY;UNTIL=2081-13-01",
+		"FREQ=MONTHLY;FOO=1",
+		"FREQ=MONTHLY;MISSINGDAY=NEXT",
+		"FREQ=MONTHLY;BYMONTHDAY=33",
+	}
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			_, err := ParseRule(test)
+			assert.Equal(t, err != nil, true)
+		})
+	}
+}
+
+func TestRuleInVendorCalendar(t *testing.T) {
+	var vendor = vendorCalendar(t)
+	var tests = []struct {
+		name     string
+		rule     Rule
+		expected []string
+	}{
+		{"monthly", Rule{Freq: Monthly}, []string{"2080-12-30", "2081-01-30", "2081-02-30"}},
+		{"last day of every month", Rule{Freq: Monthly, ByMonthDay: []int{-1}}, []string{"2080-12-30", "2081-01-30", "2081-02-32"}},
+		{"yearly", Rule{Freq: Yearly, ByMonth: []int{2}, ByMonthDay: []int{32}}, []string{"2081-02-32", "2082-02-32", "2085-02-32"}},
+		{"daily", Rule{Freq: Daily}, []string{"2080-12-30", "2081-01-01", "2081-01-02"}},
+		{"until", Rule{Freq: Weekly, Until: mustNewIn(t, vendor, "2081-01-14")}, []string{"2080-12-30", "2081-01-07", "2081-01-14"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			occurrences, err := test.rule.Expand(mustNewIn(t, vendor, "2080-12-30"), 3)
+			assert.Equal(t, err, nil)
+			var dates []string
+			for _, d := range occurrences {
+				assert.Equal(t, CalendarOf(d), vendor)
+				dates = append(dates, formatForTest(d))
+			}
+			assert.Equal(t, dates, test.expected)
+		})
+	}
+
+	_, err := Rule{Freq: Daily, Until: mustNew(t, "2081-01-14")}.Iterate(mustNewIn(t, vendor, "2081-01-01"))
+	assert.Equal(t, err, ErrMixedCalendars)
+}
@@ -0,0 +1,96 @@
+package bsdate
+
+import "strings"
+
+// RelativeOptions decide which unit Relative uses
+type RelativeOptions struct {
+	// MaxDays is the longest distance written in days.
+	// Longer distances are written in weeks until a whole BS month has passed
+	MaxDays int
+	// MaxMonths is the most BS months written in months, longer distances are written in years
+	MaxMonths int
+	// NameMonths writes e.g. "last Shrawan" instead of "2 months ago"
+	NameMonths bool
+}
+
+// DefaultRelativeOptions are used by Relative
+var DefaultRelativeOptions = RelativeOptions{MaxDays: 6, MaxMonths: 11}
+
+// Relative describes when "to" is, seen from "from", e.g. "3 days ago", "next month" or "in 2 years".
+// Months and years are counted like ages (see AgeAt), so they follow the varying length of the BS months.
+// A nil locale is English. ErrMixedCalendars is returned if the dates belong to different calendars
+func Relative(from, to Date, locale *Locale) (string, error) {
+	return RelativeWithOptions(from, to, locale, DefaultRelativeOptions)
+}
+
+// RelativeWithOptions is Relative with other thresholds
+func RelativeWithOptions(from, to Date, locale *Locale, options RelativeOptions) (string, error) {
+	locale = orEnglish(locale)
+	var words = locale.relativeWords()
+	days, err := CalendarOf(from).DaysBetween(from, to)
+	if err != nil {
+		return "", err
+	}
+	switch days {
+	case 0:
+		return words.Today, nil
+	case 1:
+		return words.Tomorrow, nil
+	case -1:
+		return words.Yesterday, nil
+	}
+	var sign, distance = 1, days
+	var earlier, later = from, to
+	if days < 0 {
+		sign, distance = -1, -days
+		earlier, later = to, from
+	}
+	var describe = func(count int, unit Plural) string {
+		var text = strings.Replace(unit.For(count), "%s", locale.FormatNumber(count), 1)
+		if sign < 0 {
+			return strings.Replace(words.Past, "%s", text, 1)
+		}
+		return strings.Replace(words.Future, "%s", text, 1)
+	}
+
+	if distance <= options.MaxDays {
+		return describe(distance, words.Days), nil
+	}
+	var months = 0
+	if age, err := AgeAt(earlier, later); err == nil {
+		months = age.Years*12 + age.Months
+	}
+	if months == 0 {
+		if distance < 7 {
+			return describe(distance, words.Days), nil
+		}
+		return describe(distance/7, words.Weeks), nil
+	}
+	if months <= options.MaxMonths {
+		var calendarMonths = (later.GetYear()-earlier.GetYear())*12 + later.GetMonth() - earlier.GetMonth()
+		if options.NameMonths && calendarMonths < 12 {
+			var phrase = words.NextNamedMonth
+			if sign < 0 {
+				phrase = words.LastNamedMonth
+			}
+			return strings.Replace(phrase, "%s", locale.MonthNames[to.GetMonth()-1], 1), nil
+		}
+		if months == 1 {
+			return choose(sign, words.NextMonth, words.LastMonth), nil
+		}
+		return describe(months, words.Months), nil
+	}
+	//with a MaxMonths below 11 there are distances of more months than that but less than a year
+	var years = months / 12
+	if years <= 1 {
+		return choose(sign, words.NextYear, words.LastYear), nil
+	}
+	return describe(years, words.Years), nil
+}
+
+func choose(sign int, future, past string) string {
+	if sign < 0 {
+		return past
+	}
+	return future
+}
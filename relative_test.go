@@ -0,0 +1,90 @@
+package bsdate
+
+import (
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestRelative(t *testing.T) {
+	var from = mustNew(t, "2081-02-15")
+	var tests = []struct {
+		to      string
+		english string
+		nepali  string
+	}{
+		{"2081-02-15", "today", "आज"},
+		{"2081-02-16", "tomorrow", "भोलि"},
+		{"2081-02-14", "yesterday", "हिजो"},
+		{"2081-02-12", "3 days ago", "३ दिन अगाडि"},
+		{"2081-02-20", "in 5 days", "५ दिन पछि"},
+		{"2081-02-25", "in 1 week", "१ हप्ता पछि"},
+		{"2081-03-14", "in 4 weeks", "४ हप्ता पछि"}, //30 days, but Jestha 2081 has 31
+		{"2081-03-15", "next month", "अर्को महिना"},
+		{"2081-01-15", "last month", "गत महिना"},
+		{"2080-12-10", "2 months ago", "२ महिना अगाडि"},
+		{"2082-02-15", "next year", "अर्को वर्ष"},
+		{"2082-02-14", "in 11 months", "११ महिना पछि"},
+		{"2078-02-01", "3 years ago", "३ वर्ष अगाडि"},
+	}
+	for _, test := range tests {
+		t.Run(test.to, func(t *testing.T) {
+			var to = mustNew(t, test.to)
+			assert.Equal(t, relativeForTest(t, from, to, English, DefaultRelativeOptions), test.english)
+			assert.Equal(t, relativeForTest(t, from, to, Nepali, DefaultRelativeOptions), test.nepali)
+		})
+	}
+}
+
+// relativeForTest calls RelativeWithOptions and fails the test on errors
+func relativeForTest(t *testing.T, from, to Date, locale *Locale, options RelativeOptions) string {
+	text, err := RelativeWithOptions(from, to, locale, options)
+	assert.Equal(t, err, nil)
+	return text
+}
+
+func TestRelativeWithOptions(t *testing.T) {
+	var from = mustNew(t, "2081-02-15")
+	var options = RelativeOptions{MaxDays: 45, MaxMonths: 18}
+	assert.Equal(t, relativeForTest(t, from, mustNew(t, "2081-03-15"), English, options), "in 31 days")
+	assert.Equal(t, relativeForTest(t, from, mustNew(t, "2082-04-15"), English, options), "in 14 months")
+	assert.Equal(t, relativeForTest(t, from, mustNew(t, "2083-03-20"), English, options), "in 2 years")
+
+	options = RelativeOptions{MaxDays: 6, MaxMonths: 11, NameMonths: true}
+	var ashwin = mustNew(t, "2081-06-10")
+	assert.Equal(t, relativeForTest(t, ashwin, mustNew(t, "2081-04-05"), English, options), "last Shrawan")
+	assert.Equal(t, relativeForTest(t, ashwin, mustNew(t, "2081-04-05"), Nepali, options), "गत साउन")
+	assert.Equal(t, relativeForTest(t, ashwin, mustNew(t, "2082-01-20"), Nepali, options), "आउँदो बैशाख")
+	assert.Equal(t, relativeForTest(t, ashwin, mustNew(t, "2081-06-20"), English, options), "in 1 week")
+}
+
+func TestRelativeShortMaxMonths(t *testing.T) {
+	var from = mustNew(t, "2081-01-01")
+	var options = RelativeOptions{MaxDays: 6, MaxMonths: 6}
+	assert.Equal(t, relativeForTest(t, from, mustNew(t, "2081-07-01"), English, options), "in 6 months")
+	assert.Equal(t, relativeForTest(t, from, mustNew(t, "2081-10-01"), English, options), "next year")
+	assert.Equal(t, relativeForTest(t, mustNew(t, "2081-10-01"), from, English, options), "last year")
+	assert.Equal(t, relativeForTest(t, from, mustNew(t, "2083-03-01"), English, options), "in 2 years")
+}
+
+func TestRelativeNilLocale(t *testing.T) {
+	var from = mustNew(t, "2081-02-15")
+	text, err := Relative(from, mustNew(t, "2081-02-20"), nil)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, text, "in 5 days")
+	assert.Equal(t, relativeForTest(t, from, mustNew(t, "2081-02-14"), nil, DefaultRelativeOptions), "yesterday")
+}
+
+func TestRelativeInVendorCalendar(t *testing.T) {
+	var vendor = vendorCalendar(t)
+	var from = mustNewIn(t, vendor, "2081-01-25")
+	//7 days in the default calendar
+	assert.Equal(t, relativeForTest(t, from, mustNewIn(t, vendor, "2081-02-01"), English, DefaultRelativeOptions), "in 6 days")
+	assert.Equal(t, relativeForTest(t, from, mustNewIn(t, vendor, "2081-02-25"), English, DefaultRelativeOptions), "next month")
+	assert.Equal(t, relativeForTest(t, from, mustNewIn(t, vendor, "2082-01-25"), English, DefaultRelativeOptions), "next year")
+
+	_, err := Relative(mustNew(t, "2081-01-25"), from, English)
+	assert.Equal(t, err, ErrMixedCalendars)
+	_, err = RelativeWithOptions(mustNew(t, "2081-01-25"), from, English, DefaultRelativeOptions)
+	assert.Equal(t, err, ErrMixedCalendars)
+}
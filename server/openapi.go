@@ -0,0 +1,170 @@
+package server
+
+import "net/http"
+
+// openAPIDocument describes the endpoints, it has to be kept in sync with the handlers
+const openAPIDocument = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "Bikram Sambat conversion service",
+    "description": "Converts dates between Bikram Sambat (BS) and the gregorian calendar (AD).",
+    "version": "1.0.0"
+  },
+  "paths": {
+    "/v1/convert": {
+      "get": {
+        "summary": "Convert a single date",
+        "parameters": [
+          {"name": "bs", "in": "query", "description": "BS date as YYYY-MM-DD", "schema": {"type": "string"}},
+          {"name": "ad", "in": "query", "description": "gregorian date as YYYY-MM-DD", "schema": {"type": "string"}}
+        ],
+        "responses": {
+          "200": {"description": "the date in both calendars", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Conversion"}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "422": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/convert/batch": {
+      "post": {
+        "summary": "Convert up to 1000 dates, every date gets either a conversion or an error",
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {
+            "type": "object",
+            "properties": {"dates": {"type": "array", "maxItems": 1000, "items": {"$ref": "#/components/schemas/ConversionRequest"}}}
+          }}}
+        },
+        "responses": {
+          "200": {"description": "the results in the order of the request", "content": {"application/json": {"schema": {
+            "type": "object",
+            "properties": {"results": {"type": "array", "items": {"$ref": "#/components/schemas/BatchResult"}}}
+          }}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "413": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/today": {
+      "get": {
+        "summary": "The current date in Nepal",
+        "responses": {
+          "200": {"description": "today in both calendars", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Conversion"}}}}
+        }
+      }
+    },
+    "/v1/calendar/{year}/{month}": {
+      "get": {
+        "summary": "All days of a BS month with their holidays",
+        "parameters": [
+          {"name": "year", "in": "path", "required": true, "schema": {"type": "integer"}},
+          {"name": "month", "in": "path", "required": true, "schema": {"type": "integer", "minimum": 1, "maximum": 12}}
+        ],
+        "responses": {
+          "200": {"description": "the month", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Calendar"}}}},
+          "400": {"description": "bad_request if year or month are no numbers, invalid_date if the month is not between 1 and 12",
+            "content": {"application/json": {"schema": {"type": "object", "properties": {"error": {"$ref": "#/components/schemas/Error"}}}}}},
+          "404": {"description": "missing_data if there is no data for the month",
+            "content": {"application/json": {"schema": {"type": "object", "properties": {"error": {"$ref": "#/components/schemas/Error"}}}}}},
+          "422": {"description": "missing_data if a day of the month cannot be converted to a gregorian date",
+            "content": {"application/json": {"schema": {"type": "object", "properties": {"error": {"$ref": "#/components/schemas/Error"}}}}}}
+        }
+      }
+    },
+    "/v1/holidays": {
+      "get": {
+        "summary": "Holidays of a BS year or month",
+        "parameters": [
+          {"name": "year", "in": "query", "required": true, "schema": {"type": "integer"}},
+          {"name": "month", "in": "query", "schema": {"type": "integer", "minimum": 1, "maximum": 12}}
+        ],
+        "responses": {
+          "200": {"description": "the holidays sorted by date", "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Holiday"}}}}},
+          "400": {"$ref": "#/components/responses/Error"},
+          "404": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/openapi.json": {
+      "get": {
+        "summary": "This document",
+        "responses": {"200": {"description": "the OpenAPI document"}}
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "ConversionRequest": {
+        "type": "object",
+        "description": "either bs or ad",
+        "properties": {"bs": {"type": "string", "example": "2081-01-15"}, "ad": {"type": "string", "example": "2024-04-27"}}
+      },
+      "Conversion": {
+        "type": "object",
+        "properties": {
+          "bs": {"type": "string", "example": "2081-01-15"},
+          "ad": {"type": "string", "example": "2024-04-27"},
+          "year": {"type": "integer"},
+          "month": {"type": "integer"},
+          "day": {"type": "integer"},
+          "month_name": {"type": "string", "example": "Baisakh"},
+          "weekday": {"type": "string", "example": "Saturday"}
+        }
+      },
+      "BatchResult": {
+        "description": "a conversion or an error",
+        "oneOf": [
+          {"$ref": "#/components/schemas/Conversion"},
+          {"type": "object", "properties": {"error": {"$ref": "#/components/schemas/Error"}}}
+        ]
+      },
+      "Calendar": {
+        "type": "object",
+        "properties": {
+          "year": {"type": "integer"},
+          "month": {"type": "integer"},
+          "name": {"type": "string"},
+          "weeks": {"type": "array", "description": "weeks from Sunday to Saturday, 0 for days of other months", "items": {"type": "array", "items": {"type": "integer"}}},
+          "days": {"type": "array", "items": {"allOf": [
+            {"$ref": "#/components/schemas/Conversion"},
+            {"type": "object", "properties": {"holidays": {"type": "array", "items": {"type": "string"}}}}
+          ]}}
+        }
+      },
+      "Holiday": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "string"},
+          "name_ne": {"type": "string"},
+          "month": {"type": "integer"},
+          "day": {"type": "integer"},
+          "category": {"type": "string", "enum": ["national", "cultural", "memorial"]},
+          "applicability": {"type": "string", "enum": ["all", "government"]},
+          "from_year": {"type": "integer"},
+          "to_year": {"type": "integer"},
+          "bs": {"type": "string"},
+          "ad": {"type": "string"}
+        }
+      },
+      "Error": {
+        "type": "object",
+        "properties": {
+          "code": {"type": "string", "enum": ["invalid_date", "missing_data", "bad_request", "not_found", "method_not_allowed", "batch_too_large"]},
+          "message": {"type": "string"}
+        }
+      }
+    },
+    "responses": {
+      "Error": {
+        "description": "the request could not be answered",
+        "content": {"application/json": {"schema": {"type": "object", "properties": {"error": {"$ref": "#/components/schemas/Error"}}}}}
+      }
+    }
+  }
+}
+`
+
+func handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_, _ = w.Write([]byte(openAPIDocument))
+}
@@ -0,0 +1,319 @@
+// Package server offers the conversions of this module as a JSON web service.
+//
+//	GET  /v1/convert?bs=2081-01-15     or ?ad=2024-04-27
+//	POST /v1/convert/batch             {"dates": [{"bs": "2081-01-15"}, {"ad": "2024-04-27"}]}
+//	GET  /v1/today
+//	GET  /v1/calendar/{year}/{month}
+//	GET  /v1/holidays?year=2081        optionally &month=2
+//	GET  /openapi.json
+//
+// Errors are answered with a matching status code and a body like
+//
+//	{"error": {"code": "invalid_date", "message": "not a valid date"}}
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+)
+
+const gregorianLayout = "2006-01-02"
+
+// MaxBatchSize is the most dates a single batch request can convert
+const MaxBatchSize = 1000
+
+// error codes used in the error bodies
+const (
+	codeInvalidDate      = "invalid_date"
+	codeMissingData      = "missing_data"
+	codeBadRequest       = "bad_request"
+	codeNotFound         = "not_found"
+	codeMethodNotAllowed = "method_not_allowed"
+	codeBatchTooLarge    = "batch_too_large"
+)
+
+// Server answers the requests, it is safe for concurrent use
+type Server struct {
+	holidays *bsdate.HolidayRegistry
+	mux      *http.ServeMux
+	//now is replaced in the tests
+	now func() time.Time
+}
+
+// New creates a server that lists the holidays of the registry, nil means bsdate.DefaultHolidayRegistry
+func New(holidays *bsdate.HolidayRegistry) *Server {
+	if holidays == nil {
+		holidays = bsdate.DefaultHolidayRegistry()
+	}
+	var s = &Server{holidays: holidays, mux: http.NewServeMux(), now: time.Now}
+	s.mux.HandleFunc("/v1/convert", s.only(http.MethodGet, s.handleConvert))
+	s.mux.HandleFunc("/v1/convert/batch", s.only(http.MethodPost, s.handleBatch))
+	s.mux.HandleFunc("/v1/today", s.only(http.MethodGet, s.handleToday))
+	s.mux.HandleFunc("/v1/calendar/", s.only(http.MethodGet, s.handleCalendar))
+	s.mux.HandleFunc("/v1/holidays", s.only(http.MethodGet, s.handleHolidays))
+	s.mux.HandleFunc("/openapi.json", s.only(http.MethodGet, handleOpenAPI))
+	s.mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, codeNotFound, "there is nothing at "+r.URL.Path)
+	})
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// APIError describes what went wrong
+type APIError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorBody is the body of every error response
+type ErrorBody struct {
+	Error APIError `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, ErrorBody{APIError{Code: code, Message: message}})
+}
+
+func (s *Server) only(method string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			writeError(w, http.StatusMethodNotAllowed, codeMethodNotAllowed, r.Method+" is not allowed, use "+method)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// Conversion is a date in both calendars
+type Conversion struct {
+	BS        string `json:"bs"`
+	AD        string `json:"ad"`
+	Year      int    `json:"year"`
+	Month     int    `json:"month"`
+	Day       int    `json:"day"`
+	MonthName string `json:"month_name"`
+	Weekday   string `json:"weekday"`
+}
+
+func newConversion(d bsdate.Date, gregorianDate time.Time) Conversion {
+	return Conversion{
+		BS:        bsdate.Format(d, bsdate.ISOLayout),
+		AD:        gregorianDate.Format(gregorianLayout),
+		Year:      d.GetYear(),
+		Month:     d.GetMonth(),
+		Day:       d.GetDay(),
+		MonthName: d.GetMonthName(),
+		Weekday:   gregorianDate.Weekday().String(),
+	}
+}
+
+// ConversionRequest asks to convert either a BS or a gregorian date
+type ConversionRequest struct {
+	BS string `json:"bs,omitempty"`
+	AD string `json:"ad,omitempty"`
+}
+
+// convert returns the conversion or the error to report
+func convert(request ConversionRequest) (Conversion, *APIError) {
+	switch {
+	case request.BS != "" && request.AD != "":
+		return Conversion{}, &APIError{codeBadRequest, "give either bs or ad, not both"}
+	case request.BS != "":
+		d, err := bsdate.Parse(bsdate.ISOLayout, request.BS)
+		if err != nil {
+			return Conversion{}, &APIError{codeInvalidDate, err.Error()}
+		}
+		gregorianDate, err := d.GetGregorianDate()
+		if err != nil {
+			return Conversion{}, &APIError{codeMissingData, err.Error()}
+		}
+		return newConversion(d, gregorianDate), nil
+	case request.AD != "":
+		gregorianDate, err := time.Parse(gregorianLayout, request.AD)
+		if err != nil {
+			return Conversion{}, &APIError{codeInvalidDate, request.AD + " is not a date in the form YYYY-MM-DD"}
+		}
+		d, err := bsdate.NewFromGregorian(gregorianDate.Day(), int(gregorianDate.Month()), gregorianDate.Year())
+		if err != nil {
+			return Conversion{}, &APIError{codeMissingData, err.Error()}
+		}
+		return newConversion(d, gregorianDate), nil
+	}
+	return Conversion{}, &APIError{codeBadRequest, "give a date as bs or ad"}
+}
+
+func (s *Server) handleConvert(w http.ResponseWriter, r *http.Request) {
+	var query = r.URL.Query()
+	conversion, apiErr := convert(ConversionRequest{BS: query.Get("bs"), AD: query.Get("ad")})
+	if apiErr != nil {
+		writeError(w, statusOf(apiErr.Code), apiErr.Code, apiErr.Message)
+		return
+	}
+	writeJSON(w, http.StatusOK, conversion)
+}
+
+func statusOf(code string) int {
+	if code == codeMissingData {
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadRequest
+}
+
+// BatchRequest is the body of a batch conversion
+type BatchRequest struct {
+	Dates []ConversionRequest `json:"dates"`
+}
+
+// BatchResult has either the conversion or the error of a single date
+type BatchResult struct {
+	*Conversion
+	Error *APIError `json:"error,omitempty"`
+}
+
+// BatchResponse has a result for every date of the request, in the same order
+type BatchResponse struct {
+	Results []BatchResult `json:"results"`
+}
+
+// handleBatch converts all dates, a single invalid date does not fail the whole request
+func (s *Server) handleBatch(w http.ResponseWriter, r *http.Request) {
+	var request BatchRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, codeBadRequest, "body is not valid JSON: "+err.Error())
+		return
+	}
+	if len(request.Dates) > MaxBatchSize {
+		writeError(w, http.StatusRequestEntityTooLarge, codeBatchTooLarge,
+			"a batch can have at most "+strconv.Itoa(MaxBatchSize)+" dates")
+		return
+	}
+	var response = BatchResponse{Results: make([]BatchResult, len(request.Dates))}
+	for i, date := range request.Dates {
+		conversion, apiErr := convert(date)
+		if apiErr != nil {
+			response.Results[i].Error = apiErr
+			continue
+		}
+		response.Results[i].Conversion = &conversion
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) handleToday(w http.ResponseWriter, r *http.Request) {
+	var now = s.now().In(bsdate.NepalTime)
+	conversion, apiErr := convert(ConversionRequest{AD: now.Format(gregorianLayout)})
+	if apiErr != nil {
+		writeError(w, http.StatusInternalServerError, apiErr.Code, apiErr.Message)
+		return
+	}
+	writeJSON(w, http.StatusOK, conversion)
+}
+
+// CalendarDay is a day of a month in the calendar response
+type CalendarDay struct {
+	Conversion
+	Holidays []string `json:"holidays"`
+}
+
+// CalendarResponse is a BS month
+type CalendarResponse struct {
+	Year  int           `json:"year"`
+	Month int           `json:"month"`
+	Name  string        `json:"name"`
+	Weeks [][7]int      `json:"weeks"`
+	Days  []CalendarDay `json:"days"`
+}
+
+func (s *Server) handleCalendar(w http.ResponseWriter, r *http.Request) {
+	var parts = strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/v1/calendar/"), "/"), "/")
+	if len(parts) != 2 {
+		writeError(w, http.StatusNotFound, codeNotFound, "use /v1/calendar/{year}/{month}")
+		return
+	}
+	year, yearErr := strconv.Atoi(parts[0])
+	month, monthErr := strconv.Atoi(parts[1])
+	if yearErr != nil || monthErr != nil {
+		writeError(w, http.StatusBadRequest, codeBadRequest, "year and month have to be numbers")
+		return
+	}
+	if month < 1 || month > 12 {
+		writeError(w, http.StatusBadRequest, codeInvalidDate, "the month has to be between 1 and 12")
+		return
+	}
+	weeks, err := bsdate.MonthWeeks(year, month)
+	if err != nil {
+		writeError(w, http.StatusNotFound, codeMissingData, "there is no data for "+parts[0]+"/"+parts[1])
+		return
+	}
+	var response = CalendarResponse{Year: year, Month: month, Name: bsdate.MonthNames[month-1], Weeks: weeks}
+	days, _ := bsdate.DaysInMonth(year, month)
+	for day := 1; day <= days; day++ {
+		d, _ := bsdate.New(day, month, year)
+		gregorianDate, err := d.GetGregorianDate()
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, codeMissingData, err.Error())
+			return
+		}
+		var calendarDay = CalendarDay{Conversion: newConversion(d, gregorianDate), Holidays: []string{}}
+		for _, holiday := range s.holidays.HolidaysOn(d) {
+			calendarDay.Holidays = append(calendarDay.Holidays, holiday.Name)
+		}
+		response.Days = append(response.Days, calendarDay)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// HolidayResponse is a holiday in a specific year
+type HolidayResponse struct {
+	bsdate.Holiday
+	BS string `json:"bs"`
+	AD string `json:"ad"`
+}
+
+func (s *Server) handleHolidays(w http.ResponseWriter, r *http.Request) {
+	var query = r.URL.Query()
+	year, err := strconv.Atoi(query.Get("year"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeBadRequest, "year is missing or not a number")
+		return
+	}
+	if _, err := bsdate.DaysInMonth(year, 1); err != nil {
+		writeError(w, http.StatusNotFound, codeMissingData, "there is no data for the year "+query.Get("year"))
+		return
+	}
+	var holidays []bsdate.HolidayDate
+	if query.Get("month") != "" {
+		month, err := strconv.Atoi(query.Get("month"))
+		if err != nil || month < 1 || month > 12 {
+			writeError(w, http.StatusBadRequest, codeBadRequest, "month has to be a number between 1 and 12")
+			return
+		}
+		holidays = s.holidays.HolidaysIn(bsdate.YearMonth{Year: year, Month: month})
+	} else {
+		holidays = s.holidays.HolidaysInYear(year)
+	}
+	var response = []HolidayResponse{}
+	for _, holiday := range holidays {
+		var item = HolidayResponse{Holiday: holiday.Holiday, BS: bsdate.Format(holiday.Date, bsdate.ISOLayout)}
+		if gregorianDate, err := holiday.Date.GetGregorianDate(); err == nil {
+			item.AD = gregorianDate.Format(gregorianLayout)
+		}
+		response = append(response, item)
+	}
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,161 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+	"github.com/magiconair/properties/assert"
+)
+
+func request(t *testing.T, s *Server, method, target, body string) (*httptest.ResponseRecorder, map[string]interface{}) {
+	var recorder = httptest.NewRecorder()
+	s.ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+	assert.Equal(t, recorder.Header().Get("Content-Type"), "application/json; charset=utf-8")
+	var decoded map[string]interface{}
+	if strings.HasPrefix(recorder.Body.String(), "{") {
+		if err := json.Unmarshal(recorder.Body.Bytes(), &decoded); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return recorder, decoded
+}
+
+func TestConvert(t *testing.T) {
+	var s = New(nil)
+	var tests = []struct {
+		target string
+		bs     string
+		ad     string
+	}{
+		{"/v1/convert?bs=2081-01-01", "2081-01-01", "2024-04-13"},
+		{"/v1/convert?ad=2024-07-15", "2081-03-32", "2024-07-15"},
+	}
+	for _, test := range tests {
+		t.Run(test.target, func(t *testing.T) {
+			recorder, body := request(t, s, http.MethodGet, test.target, "")
+			assert.Equal(t, recorder.Code, http.StatusOK)
+			assert.Equal(t, body["bs"], test.bs)
+			assert.Equal(t, body["ad"], test.ad)
+		})
+	}
+	_, body := request(t, s, http.MethodGet, "/v1/convert?bs=2081-01-01", "")
+	assert.Equal(t, body["month_name"], "Baisakh")
+	assert.Equal(t, body["weekday"], "Saturday")
+	assert.Equal(t, body["year"], 2081.0)
+}
+
+func TestErrors(t *testing.T) {
+	var s = New(nil)
+	var tests = []struct {
+		method string
+		target string
+		body   string
+		status int
+		code   string
+	}{
+		{http.MethodGet, "/v1/convert?bs=2081-02-32", "", http.StatusBadRequest, "invalid_date"},
+		{http.MethodGet, "/v1/convert?ad=2024-13-01", "", http.StatusBadRequest, "invalid_date"},
+		{http.MethodGet, "/v1/convert?ad=1800-01-01", "", http.StatusUnprocessableEntity, "missing_data"},
+		{http.MethodGet, "/v1/convert", "", http.StatusBadRequest, "bad_request"},
+		{http.MethodGet, "/v1/convert?bs=2081-01-01&ad=2024-04-13", "", http.StatusBadRequest, "bad_request"},
+		{http.MethodPost, "/v1/convert", "", http.StatusMethodNotAllowed, "method_not_allowed"},
+		{http.MethodGet, "/v1/convert/batch", "", http.StatusMethodNotAllowed, "method_not_allowed"},
+		{http.MethodPost, "/v1/convert/batch", "{", http.StatusBadRequest, "bad_request"},
+		{http.MethodPost, "/v1/convert/batch", `{"dates": [` + strings.Repeat(`{"bs": "2081-01-01"},`, MaxBatchSize) + `{}]}`,
+			http.StatusRequestEntityTooLarge, "batch_too_large"},
+		{http.MethodGet, "/v1/calendar/2081", "", http.StatusNotFound, "not_found"},
+		{http.MethodGet, "/v1/calendar/2081/x", "", http.StatusBadRequest, "bad_request"},
+		{http.MethodGet, "/v1/calendar/2081/0", "", http.StatusBadRequest, "invalid_date"},
+		{http.MethodGet, "/v1/calendar/2081/13", "", http.StatusBadRequest, "invalid_date"},
+		{http.MethodGet, "/v1/calendar/2200/1", "", http.StatusNotFound, "missing_data"},
+		{http.MethodGet, "/v1/calendar/1970/1", "", http.StatusUnprocessableEntity, "missing_data"},
+		{http.MethodGet, "/v1/holidays", "", http.StatusBadRequest, "bad_request"},
+		{http.MethodGet, "/v1/holidays?year=2081&month=13", "", http.StatusBadRequest, "bad_request"},
+		{http.MethodGet, "/v1/holidays?year=2200", "", http.StatusNotFound, "missing_data"},
+		{http.MethodGet, "/v2/convert", "", http.StatusNotFound, "not_found"},
+	}
+	for _, test := range tests {
+		t.Run(test.method+" "+test.target, func(t *testing.T) {
+			recorder, body := request(t, s, test.method, test.target, test.body)
+			assert.Equal(t, recorder.Code, test.status)
+			var apiErr = body["error"].(map[string]interface{})
+			assert.Equal(t, apiErr["code"], test.code)
+			assert.Equal(t, apiErr["message"] != "", true)
+		})
+	}
+}
+
+func TestBatch(t *testing.T) {
+	var s = New(nil)
+	var recorder = httptest.NewRecorder()
+	s.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/v1/convert/batch",
+		strings.NewReader(`{"dates": [{"bs": "2081-01-01"}, {"ad": "2024-07-15"}, {"bs": "2081-02-32"}]}`)))
+	assert.Equal(t, recorder.Code, http.StatusOK)
+	var response BatchResponse
+	assert.Equal(t, json.Unmarshal(recorder.Body.Bytes(), &response), nil)
+	assert.Equal(t, len(response.Results), 3)
+	assert.Equal(t, response.Results[0].AD, "2024-04-13")
+	assert.Equal(t, response.Results[1].BS, "2081-03-32")
+	assert.Equal(t, response.Results[2].Conversion == nil, true)
+	assert.Equal(t, response.Results[2].Error.Code, "invalid_date")
+}
+
+func TestToday(t *testing.T) {
+	var s = New(nil)
+	//still the 12th of April in UTC, but already the 1st of Baisakh in Nepal
+	s.now = func() time.Time {
+		return time.Date(2024, 4, 12, 20, 0, 0, 0, time.UTC)
+	}
+	recorder, body := request(t, s, http.MethodGet, "/v1/today", "")
+	assert.Equal(t, recorder.Code, http.StatusOK)
+	assert.Equal(t, body["bs"], "2081-01-01")
+}
+
+func TestCalendar(t *testing.T) {
+	var s = New(nil)
+	var recorder = httptest.NewRecorder()
+	s.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/calendar/2081/2", nil))
+	assert.Equal(t, recorder.Code, http.StatusOK)
+	var response CalendarResponse
+	assert.Equal(t, json.Unmarshal(recorder.Body.Bytes(), &response), nil)
+	assert.Equal(t, response.Name, "Jestha")
+	assert.Equal(t, len(response.Days), 31)
+	assert.Equal(t, response.Weeks[0], [7]int{0, 0, 1, 2, 3, 4, 5})
+	assert.Equal(t, response.Days[14].AD, "2024-05-28")
+	assert.Equal(t, response.Days[14].Holidays, []string{"Republic Day"})
+	assert.Equal(t, response.Days[15].Holidays, []string{})
+}
+
+func TestHolidays(t *testing.T) {
+	registry, err := bsdate.NewHolidayRegistry(bsdate.Holiday{Name: "Founding Day", Month: 4, Day: 1})
+	assert.Equal(t, err, nil)
+	var s = New(registry)
+	var recorder = httptest.NewRecorder()
+	s.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/holidays?year=2081", nil))
+	assert.Equal(t, recorder.Code, http.StatusOK)
+	var response []HolidayResponse
+	assert.Equal(t, json.Unmarshal(recorder.Body.Bytes(), &response), nil)
+	assert.Equal(t, len(response), 1)
+	assert.Equal(t, response[0].Name, "Founding Day")
+	assert.Equal(t, response[0].BS, "2081-04-01")
+	assert.Equal(t, response[0].AD, "2024-07-16")
+
+	recorder = httptest.NewRecorder()
+	s.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/v1/holidays?year=2081&month=5", nil))
+	assert.Equal(t, recorder.Body.String(), "[]\n")
+}
+
+func TestOpenAPI(t *testing.T) {
+	recorder, body := request(t, New(nil), http.MethodGet, "/openapi.json", "")
+	assert.Equal(t, recorder.Code, http.StatusOK)
+	assert.Equal(t, body["openapi"], "3.0.3")
+	var paths = body["paths"].(map[string]interface{})
+	for _, path := range []string{"/v1/convert", "/v1/convert/batch", "/v1/today", "/v1/calendar/{year}/{month}", "/v1/holidays"} {
+		assert.Equal(t, paths[path] != nil, true, path)
+	}
+}
@@ -0,0 +1,108 @@
+package bsdate
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"text/template"
+	"time"
+)
+
+// templateToday is replaced in the tests
+var templateToday = Today
+
+// TemplateFuncs returns functions for text/template. For html/template convert the result, both map types are the same:
+//
+//	htmltemplate.New("page").Funcs(htmltemplate.FuncMap(bsdate.TemplateFuncs()))
+//
+// Every function takes the date as its last argument, so they can be used in pipelines. The date can be
+// a Date, a time.Time whose calendar day is converted (use .In(bsdate.NepalTime) first for the day in Nepal)
+// or a BS date written as YYYY-MM-DD. nil, an empty string and the zero time.Time give an empty result.
+//
+//	bs DATE               the BS date as YYYY-MM-DD, e.g. {{bs .Created}}
+//	bsformat LAYOUT DATE  the BS date in a layout of Format, e.g. {{.Created | bsformat "D MMMM YYYY"}}
+//	bsdevanagari VALUE    a date as YYYY-MM-DD or any other value with Devanagari digits,
+//	                      e.g. {{.Created | bsformat "D MMMM YYYY" | bsdevanagari}}
+//	bsmonthname DATE      the name of the BS month, DATE may also be a month number
+//	bsfiscalyear DATE     the fiscal year the date is in, e.g. 2081/82
+//	bsrelative DATE       how far the date is from today in english, e.g. "in 3 days", see Relative
+//
+// Dates that cannot be converted stop the execution of the template with an error.
+// All functions return plain strings, so html/template escapes them like any other value
+func TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"bs": func(value interface{}) (string, error) {
+			return formatTemplateDate(value, ISOLayout)
+		},
+		"bsformat": func(layout string, value interface{}) (string, error) {
+			return formatTemplateDate(value, layout)
+		},
+		"bsdevanagari": func(value interface{}) (string, error) {
+			switch value.(type) {
+			case Date, time.Time:
+				formatted, err := formatTemplateDate(value, ISOLayout)
+				return ToDevanagariDigits(formatted), err
+			case nil:
+				return "", nil
+			}
+			return ToDevanagariDigits(fmt.Sprint(value)), nil
+		},
+		"bsmonthname": func(value interface{}) (string, error) {
+			if month, ok := value.(int); ok {
+				if month < 1 || month > 12 {
+					return "", ErrInvalidDate
+				}
+				return MonthNames[month-1], nil
+			}
+			return formatTemplateDate(value, "MMMM")
+		},
+		"bsfiscalyear": func(value interface{}) (string, error) {
+			d, err := templateDate(value)
+			if d == nil || err != nil {
+				return "", err
+			}
+			var year = FiscalYear(d)
+			return strconv.Itoa(year) + "/" + padNumber((year+1)%100, 2), nil
+		},
+		"bsrelative": func(value interface{}) (string, error) {
+			d, err := templateDate(value)
+			if d == nil || err != nil {
+				return "", err
+			}
+			today, err := templateToday()
+			if err != nil {
+				return "", err
+			}
+			return Relative(today, d, English)
+		},
+	}
+}
+
+// templateDate turns the argument of a template function into a Date, it is nil for empty values
+func templateDate(value interface{}) (Date, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case Date:
+		return v, nil
+	case time.Time:
+		if v.IsZero() {
+			return nil, nil
+		}
+		return NewFromGregorian(v.Day(), int(v.Month()), v.Year())
+	case string:
+		if v == "" {
+			return nil, nil
+		}
+		return Parse(ISOLayout, v)
+	}
+	return nil, errors.New("cannot use a value of type " + fmt.Sprintf("%T", value) + " as a date")
+}
+
+func formatTemplateDate(value interface{}, layout string) (string, error) {
+	d, err := templateDate(value)
+	if d == nil || err != nil {
+		return "", err
+	}
+	return Format(d, layout), nil
+}
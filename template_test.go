@@ -0,0 +1,79 @@
+package bsdate
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func executeForTest(t *testing.T, text string, data interface{}) (string, error) {
+	var output bytes.Buffer
+	var tmpl = template.Must(template.New("test").Funcs(TemplateFuncs()).Parse(text))
+	err := tmpl.Execute(&output, data)
+	return output.String(), err
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	templateToday = func() (Date, error) { return New(15, 2, 2081) }
+	defer func() { templateToday = Today }()
+
+	var data = map[string]interface{}{
+		"Time":   time.Date(2024, 7, 15, 23, 0, 0, 0, time.UTC),
+		"Date":   mustNew(t, "2081-02-05"),
+		"String": "2081-04-01",
+		"Zero":   time.Time{},
+	}
+	var tests = []struct {
+		template string
+		expected string
+	}{
+		{`{{bs .Time}}`, "2081-03-32"},
+		{`{{bs .Date}} {{bs .String}}`, "2081-02-05 2081-04-01"},
+		{`{{.Time | bsformat "D MMMM YYYY"}}`, "32 Ashadh 2081"},
+		{`{{.Date | bsformat "D MMMM YYYY" | bsdevanagari}}`, "५ Jestha २०८१"},
+		{`{{bsdevanagari .Date}} {{bsdevanagari 42}}`, "२०८१-०२-०५ ४२"},
+		{`{{bsmonthname .Time}} {{bsmonthname 12}}`, "Ashadh Chaitra"},
+		{`{{bsfiscalyear .Time}} {{bsfiscalyear .String}}`, "2080/81 2081/82"},
+		{`{{bsrelative .Date}}, {{bsrelative "2081-02-16"}}, {{bsrelative "2081-02-14"}}`, "1 week ago, tomorrow, yesterday"},
+		{`{{bsrelative .String}}`, "next month"},
+		{`[{{bs .Zero}}{{bs .Missing}}{{bs ""}}{{bsrelative nil}}]`, "[]"},
+	}
+	for _, test := range tests {
+		t.Run(test.template, func(t *testing.T) {
+			output, err := executeForTest(t, test.template, data)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, output, test.expected)
+		})
+	}
+}
+
+func TestTemplateFuncsErrors(t *testing.T) {
+	var tests = []struct {
+		template string
+		data     interface{}
+		expected string
+	}{
+		{`{{bs .}}`, "2081-01-32", "not a valid date"},
+		{`{{bs .}}`, time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC), ErrMissingData.Error()},
+		{`{{bs .}}`, 2081, "cannot use a value of type int as a date"},
+		{`{{bsmonthname .}}`, 13, ErrInvalidDate.Error()},
+	}
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			_, err := executeForTest(t, test.template, test.data)
+			assert.Matches(t, err.Error(), test.expected)
+		})
+	}
+}
+
+func TestTemplateFuncsInHTML(t *testing.T) {
+	var output bytes.Buffer
+	var tmpl = htmltemplate.Must(htmltemplate.New("test").Funcs(htmltemplate.FuncMap(TemplateFuncs())).
+		Parse(`<a title="{{bsformat "<MMMM>" .}}">{{bsformat "<b>YYYY</b>" .}}</a>`))
+	assert.Equal(t, tmpl.Execute(&output, mustNew(t, "2081-01-01")), nil)
+	assert.Equal(t, output.String(), `<a title="&lt;Baisakh&gt;">&lt;b&gt;2081&lt;/b&gt;</a>`)
+}
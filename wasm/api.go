@@ -0,0 +1,92 @@
+package main
+
+import (
+	"errors"
+	"time"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+)
+
+// The functions in here do the work of the exported javascript functions. They only use types syscall/js can
+// convert (maps, slices of interface{}, strings, numbers and bools), so they can be tested without javascript.
+
+const gregorianLayout = "2006-01-02"
+
+// conversion has the same field names as the responses of the server package
+func conversion(d bsdate.Date, gregorianDate time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"bs":         bsdate.Format(d, bsdate.ISOLayout),
+		"ad":         gregorianDate.Format(gregorianLayout),
+		"year":       d.GetYear(),
+		"month":      d.GetMonth(),
+		"day":        d.GetDay(),
+		"month_name": d.GetMonthName(),
+		"weekday":    gregorianDate.Weekday().String(),
+	}
+}
+
+// toGregorian converts a BS date written as YYYY-MM-DD
+func toGregorian(bs string) (map[string]interface{}, error) {
+	d, err := bsdate.Parse(bsdate.ISOLayout, bs)
+	if err != nil {
+		return nil, err
+	}
+	gregorianDate, err := d.GetGregorianDate()
+	if err != nil {
+		return nil, err
+	}
+	return conversion(d, gregorianDate), nil
+}
+
+// toBS converts a gregorian date written as YYYY-MM-DD
+func toBS(ad string) (map[string]interface{}, error) {
+	gregorianDate, err := time.Parse(gregorianLayout, ad)
+	if err != nil {
+		return nil, errors.New(ad + " is not a date in the form YYYY-MM-DD")
+	}
+	d, err := bsdate.NewFromGregorian(gregorianDate.Day(), int(gregorianDate.Month()), gregorianDate.Year())
+	if err != nil {
+		return nil, err
+	}
+	return conversion(d, gregorianDate), nil
+}
+
+// isValid reports if the BS date written as YYYY-MM-DD exists
+func isValid(bs string) bool {
+	_, err := bsdate.Parse(bsdate.ISOLayout, bs)
+	return err == nil
+}
+
+// format writes the BS date given as YYYY-MM-DD in another layout, see bsdate.Format
+func format(bs, layout string) (string, error) {
+	d, err := bsdate.Parse(bsdate.ISOLayout, bs)
+	if err != nil {
+		return "", err
+	}
+	return bsdate.Format(d, layout), nil
+}
+
+func daysInMonth(year, month int) (int, error) {
+	return bsdate.DaysInMonth(year, month)
+}
+
+// monthWeeks returns the weeks of a month as arrays from Sunday to Saturday, 0 for days of other months
+func monthWeeks(year, month int) ([]interface{}, error) {
+	weeks, err := bsdate.MonthWeeks(year, month)
+	if err != nil {
+		return nil, err
+	}
+	var result = make([]interface{}, len(weeks))
+	for i, week := range weeks {
+		var days = make([]interface{}, 7)
+		for weekday, day := range week {
+			days[weekday] = day
+		}
+		result[i] = days
+	}
+	return result, nil
+}
+
+func monthGrid(year, month int, options bsdate.GridOptions) (string, error) {
+	return bsdate.MonthGrid(year, month, options)
+}
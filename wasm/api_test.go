@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+)
+
+func TestToGregorian(t *testing.T) {
+	conversion, err := toGregorian("2081-02-15")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, conversion, map[string]interface{}{
+		"bs": "2081-02-15", "ad": "2024-05-28", "year": 2081, "month": 2, "day": 15,
+		"month_name": "Jestha", "weekday": "Tuesday",
+	})
+
+	_, err = toGregorian("2081-01-32")
+	assert.Equal(t, err, bsdate.ErrInvalidDate)
+}
+
+func TestToBS(t *testing.T) {
+	conversion, err := toBS("2024-07-15")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, conversion["bs"], "2081-03-32")
+
+	_, err = toBS("15.07.2024")
+	assert.Equal(t, err.Error(), "15.07.2024 is not a date in the form YYYY-MM-DD")
+	_, err = toBS("1800-01-01")
+	assert.Equal(t, err, bsdate.ErrMissingData)
+}
+
+func TestIsValid(t *testing.T) {
+	assert.Equal(t, isValid("2081-03-32"), true)
+	assert.Equal(t, isValid("2081-01-32"), false)
+	assert.Equal(t, isValid("2081/01/01"), false)
+}
+
+func TestFormat(t *testing.T) {
+	formatted, err := format("2081-02-05", "D MMMM YYYY")
+	assert.Equal(t, err, nil)
+	assert.Equal(t, formatted, "5 Jestha 2081")
+}
+
+func TestMonthWeeks(t *testing.T) {
+	weeks, err := monthWeeks(2081, 1)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, len(weeks), 6)
+	assert.Equal(t, weeks[0], []interface{}{0, 0, 0, 0, 0, 0, 1})
+
+	_, err = monthWeeks(2081, 13)
+	assert.Equal(t, err, bsdate.ErrInvalidDate)
+}
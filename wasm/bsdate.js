@@ -0,0 +1,63 @@
+// bsdate.js loads bsdate.wasm and offers its functions with plain javascript errors.
+// wasm_exec.js of the go installation that built bsdate.wasm has to be loaded first, it defines the Go class.
+//
+//	const bikramSambat = await BikramSambat.load("bsdate.wasm")   // a URL in browsers, or the bytes of the file
+//	bikramSambat.toGregorian("2081-01-01").ad                     // "2024-04-13"
+"use strict";
+
+(function (root, factory) {
+  if (typeof module === "object" && module.exports) {
+    module.exports = factory();
+  } else {
+    root.BikramSambat = factory();
+  }
+})(typeof self !== "undefined" ? self : this, function () {
+  // unwrap turns the {result} or {error} object returned by go into a value or an exception
+  function unwrap(response) {
+    if (response.error !== undefined) {
+      throw new Error(response.error);
+    }
+    return response.result;
+  }
+
+  async function instantiate(source, importObject) {
+    if (source instanceof ArrayBuffer || ArrayBuffer.isView(source)) {
+      return WebAssembly.instantiate(source, importObject);
+    }
+    const response = fetch(source);
+    if (WebAssembly.instantiateStreaming) {
+      return WebAssembly.instantiateStreaming(response, importObject);
+    }
+    return WebAssembly.instantiate(await (await response).arrayBuffer(), importObject);
+  }
+
+  // load starts bsdate.wasm, source is its URL or its content
+  async function load(source) {
+    const go = new Go();
+    const { instance } = await instantiate(source, go.importObject);
+    const ready = new Promise(function (resolve) {
+      globalThis.__bsdateReady = resolve;
+    });
+    go.run(instance);
+    await ready;
+    delete globalThis.__bsdateReady;
+
+    const api = globalThis.bsdate;
+    return {
+      // toGregorian converts a BS date YYYY-MM-DD, the result has the fields bs, ad, year, month, day, month_name and weekday
+      toGregorian: (bs) => unwrap(api.toGregorian(bs)),
+      // toBS converts a gregorian date YYYY-MM-DD, the result looks like the one of toGregorian
+      toBS: (ad) => unwrap(api.toBS(ad)),
+      isValid: (bs) => unwrap(api.isValid(bs)),
+      // format writes a BS date with a layout like "DD MMMM YYYY"
+      format: (bs, layout) => unwrap(api.format(bs, layout)),
+      daysInMonth: (year, month) => unwrap(api.daysInMonth(year, month)),
+      // monthWeeks returns the weeks of a month from Sunday to Saturday, days of other months are 0
+      monthWeeks: (year, month) => unwrap(api.monthWeeks(year, month)),
+      // monthGrid prints a month like the unix cal command, options are showGregorian, devanagari and highlight
+      monthGrid: (year, month, options) => unwrap(api.monthGrid(year, month, options || {})),
+    };
+  }
+
+  return { load };
+});
@@ -0,0 +1,47 @@
+// Tests the shim with node 18 or newer:
+//
+//	GOOS=js GOARCH=wasm go build -o wasm/bsdate.wasm ./wasm
+//	node wasm/bsdate.test.js
+"use strict";
+
+const assert = require("assert");
+const childProcess = require("child_process");
+const fs = require("fs");
+const path = require("path");
+const test = require("node:test");
+
+const goRoot = childProcess.execSync("go env GOROOT").toString().trim();
+const wasmExec = [path.join(goRoot, "lib", "wasm", "wasm_exec.js"), path.join(goRoot, "misc", "wasm", "wasm_exec.js")]
+  .find((file) => fs.existsSync(file));
+require(wasmExec);
+const BikramSambat = require("./bsdate.js");
+
+test("shim", async (t) => {
+  const bikramSambat = await BikramSambat.load(fs.readFileSync(path.join(__dirname, "bsdate.wasm")));
+
+  await t.test("toGregorian", () => {
+    const conversion = bikramSambat.toGregorian("2081-01-01");
+    assert.strictEqual(conversion.ad, "2024-04-13");
+    assert.strictEqual(conversion.month_name, "Baisakh");
+    assert.strictEqual(conversion.weekday, "Saturday");
+  });
+  await t.test("toBS", () => {
+    assert.strictEqual(bikramSambat.toBS("2024-07-16").bs, "2081-04-01");
+  });
+  await t.test("errors become exceptions", () => {
+    assert.throws(() => bikramSambat.toGregorian("2081-01-32"), /not a valid date/);
+    assert.throws(() => bikramSambat.toBS(42), /has to be a string/);
+  });
+  await t.test("isValid", () => {
+    assert.strictEqual(bikramSambat.isValid("2081-03-32"), true);
+    assert.strictEqual(bikramSambat.isValid("2081-01-32"), false);
+  });
+  await t.test("format", () => {
+    assert.strictEqual(bikramSambat.format("2081-02-05", "D MMMM YYYY"), "5 Jestha 2081");
+  });
+  await t.test("month", () => {
+    assert.strictEqual(bikramSambat.daysInMonth(2081, 3), 32);
+    assert.deepStrictEqual(bikramSambat.monthWeeks(2081, 1)[0], [0, 0, 0, 0, 0, 0, 1]);
+    assert.match(bikramSambat.monthGrid(2081, 1), /^ {4}Baisakh 2081\n/);
+  });
+});
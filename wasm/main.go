@@ -0,0 +1,117 @@
+//go:build js && wasm
+// +build js,wasm
+
+// Command wasm exports the conversions of this module to javascript, use it through the shim in bsdate.js.
+//
+//	GOOS=js GOARCH=wasm go build -o bsdate.wasm ./wasm
+//
+// All functions are set on the global object "bsdate" and return {result: ...} or {error: "message"},
+// the shim turns the errors into exceptions. It needs go 1.12 or newer
+package main
+
+import (
+	"errors"
+	"strconv"
+	"syscall/js"
+
+	bsdate "github.com/JankariTech/GoBikramSambat"
+)
+
+func main() {
+	register()
+	//the shim waits for this call before it uses the functions
+	if ready := js.Global().Get("__bsdateReady"); ready.Type() == js.TypeFunction {
+		ready.Invoke()
+	}
+	select {}
+}
+
+// register sets the global "bsdate" object
+func register() {
+	var api = js.Global().Get("Object").New()
+	export(api, "toGregorian", func(args []js.Value) (interface{}, error) {
+		bs, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return toGregorian(bs)
+	})
+	export(api, "toBS", func(args []js.Value) (interface{}, error) {
+		ad, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return toBS(ad)
+	})
+	export(api, "isValid", func(args []js.Value) (interface{}, error) {
+		bs, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return isValid(bs), nil
+	})
+	export(api, "format", func(args []js.Value) (interface{}, error) {
+		bs, err := stringArg(args, 0)
+		if err != nil {
+			return nil, err
+		}
+		layout, err := stringArg(args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return format(bs, layout)
+	})
+	export(api, "daysInMonth", func(args []js.Value) (interface{}, error) {
+		year, month, err := yearMonthArgs(args)
+		if err != nil {
+			return nil, err
+		}
+		return daysInMonth(year, month)
+	})
+	export(api, "monthWeeks", func(args []js.Value) (interface{}, error) {
+		year, month, err := yearMonthArgs(args)
+		if err != nil {
+			return nil, err
+		}
+		return monthWeeks(year, month)
+	})
+	export(api, "monthGrid", func(args []js.Value) (interface{}, error) {
+		year, month, err := yearMonthArgs(args)
+		if err != nil {
+			return nil, err
+		}
+		var options bsdate.GridOptions
+		if len(args) > 2 && args[2].Type() == js.TypeObject {
+			options.ShowGregorian = args[2].Get("showGregorian").Truthy()
+			options.Devanagari = args[2].Get("devanagari").Truthy()
+			options.Highlight = args[2].Get("highlight").Truthy()
+		}
+		return monthGrid(year, month, options)
+	})
+	js.Global().Set("bsdate", api)
+}
+
+// export wraps f so it returns {result: ...} or {error: "message"}
+func export(api js.Value, name string, f func(args []js.Value) (interface{}, error)) {
+	api.Set(name, js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		result, err := f(args)
+		if err != nil {
+			return map[string]interface{}{"error": err.Error()}
+		}
+		return map[string]interface{}{"result": result}
+	}))
+}
+
+func stringArg(args []js.Value, i int) (string, error) {
+	if len(args) <= i || args[i].Type() != js.TypeString {
+		return "", errors.New("argument " + strconv.Itoa(i+1) + " has to be a string")
+	}
+	return args[i].String(), nil
+}
+
+func yearMonthArgs(args []js.Value) (int, int, error) {
+	if len(args) < 2 || args[0].Type() != js.TypeNumber || args[1].Type() != js.TypeNumber {
+		return 0, 0, errors.New("year and month have to be numbers")
+	}
+	return args[0].Int(), args[1].Int(), nil
+}
@@ -0,0 +1,55 @@
+//go:build js && wasm
+// +build js,wasm
+
+package main
+
+import (
+	"os"
+	"strings"
+	"syscall/js"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+// These tests run in node:
+//
+//	GOOS=js GOARCH=wasm go test -exec="$(go env GOROOT)/lib/wasm/go_js_wasm_exec" ./wasm
+//
+// Before go 1.24 go_js_wasm_exec is in misc/wasm instead of lib/wasm.
+
+func TestMain(m *testing.M) {
+	register()
+	os.Exit(m.Run())
+}
+
+func call(name string, args ...interface{}) js.Value {
+	return js.Global().Get("bsdate").Call(name, args...)
+}
+
+func TestExportedConversions(t *testing.T) {
+	var result = call("toGregorian", "2081-01-01").Get("result")
+	assert.Equal(t, result.Get("ad").String(), "2024-04-13")
+	assert.Equal(t, result.Get("weekday").String(), "Saturday")
+	assert.Equal(t, call("toBS", "2024-07-16").Get("result").Get("bs").String(), "2081-04-01")
+	assert.Equal(t, call("isValid", "2081-01-32").Get("result").Bool(), false)
+	assert.Equal(t, call("format", "2081-02-05", "DD-MM-YYYY").Get("result").String(), "05-02-2081")
+	assert.Equal(t, call("daysInMonth", 2081, 3).Get("result").Int(), 32)
+}
+
+func TestExportedErrors(t *testing.T) {
+	assert.Equal(t, call("toGregorian", "2081-01-32").Get("error").String(), "not a valid date")
+	assert.Equal(t, call("toBS").Get("error").String(), "argument 1 has to be a string")
+	assert.Equal(t, call("monthWeeks", "2081", 1).Get("error").String(), "year and month have to be numbers")
+	assert.Equal(t, call("monthWeeks", 2081, 1).Get("error").Type(), js.TypeUndefined)
+}
+
+func TestExportedMonthGrid(t *testing.T) {
+	var weeks = call("monthWeeks", 2081, 1).Get("result")
+	assert.Equal(t, weeks.Length(), 6)
+	assert.Equal(t, weeks.Index(0).Index(6).Int(), 1)
+
+	var options = map[string]interface{}{"devanagari": true}
+	var grid = call("monthGrid", 2081, 1, options).Get("result").String()
+	assert.Equal(t, strings.HasPrefix(grid, "    Baisakh २०८१\n"), true)
+}
@@ -0,0 +1,15 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// main only exists so the package builds on every platform, the real entry point is in main.go
+func main() {
+	fmt.Fprintln(os.Stderr, "this program only runs in javascript, build it with GOOS=js GOARCH=wasm")
+	os.Exit(1)
+}
@@ -0,0 +1,231 @@
+package bsdate
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrNoNumberWords is returned for locales that cannot write numbers in words
+var ErrNoNumberWords = errors.New("the locale cannot write numbers in words")
+
+// NumberWords has the words to spell numbers in a language
+type NumberWords struct {
+	// Small are the words for 0 and up. Numbers below 100 that are not in Small are written as the word
+	// from Tens and the word for the ones, joined with TensSeparator (e.g. "Eighty-One").
+	// Languages with irregular numbers, like Nepali, list all words from 0 to 99
+	Small         []string
+	Tens          [10]string
+	TensSeparator string
+	Hundred       string
+	// Scales are the words for big numbers, from the largest to the smallest, e.g. lakh and thousand
+	Scales []NumberScale
+	Minus  string
+	// Aliases maps other spellings to the words above, they are accepted by the parsers
+	Aliases map[string]string
+}
+
+// NumberScale is a word for a big number, like thousand
+type NumberScale struct {
+	Value int64
+	Name  string
+}
+
+// NumberToWords spells out n, e.g. "Two Thousand Eighty-One" or "दुई हजार एकासी"
+func NumberToWords(n int, locale *Locale) (string, error) {
+	locale = orEnglish(locale)
+	if locale.NumberWords == nil {
+		return "", ErrNoNumberWords
+	}
+	return strings.Join(locale.NumberWords.spell(int64(n)), " "), nil
+}
+
+// spell returns the words of n, negative numbers start with Minus
+func (w *NumberWords) spell(n int64) []string {
+	if n < 0 {
+		//-n overflows for the smallest int64, so the magnitude is taken as unsigned
+		return append([]string{w.Minus}, w.words(uint64(-(n+1))+1)...)
+	}
+	return w.words(uint64(n))
+}
+
+func (w *NumberWords) words(n uint64) []string {
+	if n == 0 {
+		return []string{w.Small[0]}
+	}
+	var words []string
+	for _, scale := range w.Scales {
+		var value = uint64(scale.Value)
+		if n >= value {
+			words = append(words, w.words(n/value)...)
+			words = append(words, scale.Name)
+			n %= value
+		}
+	}
+	if n >= 100 {
+		words = append(words, w.belowHundred(n/100), w.Hundred)
+		n %= 100
+	}
+	if n > 0 {
+		words = append(words, w.belowHundred(n))
+	}
+	return words
+}
+
+func (w *NumberWords) belowHundred(n uint64) string {
+	if n < uint64(len(w.Small)) {
+		return w.Small[n]
+	}
+	if n%10 == 0 {
+		return w.Tens[n/10]
+	}
+	return w.Tens[n/10] + w.TensSeparator + w.Small[n%10]
+}
+
+// ParseNumberWords reads a number written like NumberToWords does it, case insensitive.
+// The words have to be in the same order as NumberToWords writes them, so e.g. "Twenty Thirty" is rejected
+func ParseNumberWords(s string, locale *Locale) (int, error) {
+	var w = orEnglish(locale).NumberWords
+	if w == nil {
+		return 0, ErrNoNumberWords
+	}
+	var tokens = w.tokens(s)
+	var values = map[string]int64{}
+	for i, word := range w.Small {
+		values[strings.ToLower(word)] = int64(i)
+	}
+	for i, word := range w.Tens {
+		if word != "" {
+			values[strings.ToLower(word)] = int64(i * 10)
+		}
+	}
+	var scales = map[string]int64{}
+	for _, scale := range w.Scales {
+		scales[strings.ToLower(scale.Name)] = scale.Value
+	}
+
+	var invalid = errors.New("\"" + s + "\" is not a number in words")
+	if len(tokens) == 0 {
+		return 0, invalid
+	}
+	var negative = tokens[0] == strings.ToLower(w.Minus)
+	if negative {
+		tokens = tokens[1:]
+	}
+	var total, current int64
+	for _, token := range tokens {
+		if value, ok := values[token]; ok {
+			current += value
+		} else if token == strings.ToLower(w.Hundred) && current > 0 {
+			current *= 100
+		} else if scale, ok := scales[token]; ok && current > 0 {
+			total += current * scale
+			current = 0
+		} else {
+			return 0, invalid
+		}
+	}
+	var n = total + current
+	if negative {
+		n = -n
+	}
+	//numbers like "Twenty Thirty" add up to something, but they are not how the number is written
+	if strings.Join(w.tokens(strings.Join(w.spell(n), " ")), " ") != strings.Join(w.tokens(s), " ") {
+		return 0, invalid
+	}
+	return int(n), nil
+}
+
+// tokens splits the words of a number, in lower case and with aliases replaced
+func (w *NumberWords) tokens(s string) []string {
+	s = strings.ToLower(s)
+	if w.TensSeparator != "" {
+		s = strings.Replace(s, strings.ToLower(w.TensSeparator), " ", -1)
+	}
+	var tokens = strings.Fields(s)
+	for i, token := range tokens {
+		if canonical, ok := w.Aliases[token]; ok {
+			tokens[i] = strings.ToLower(canonical)
+		}
+	}
+	return tokens
+}
+
+// DateToWords spells out a date as it is done in legal documents and on cheques, e.g.
+// "दुई हजार एकासी साल वैशाख पन्ध्र गते" or "Two Thousand Eighty-One, Baisakh Fifteen".
+// The month is written with its formal name
+func DateToWords(d Date, locale *Locale) (string, error) {
+	locale = orEnglish(locale)
+	year, err := NumberToWords(d.GetYear(), locale)
+	if err != nil {
+		return "", err
+	}
+	day, _ := NumberToWords(d.GetDay(), locale)
+	var replacer = strings.NewReplacer(
+		"{year}", year,
+		"{month}", locale.FormalMonthNames[d.GetMonth()-1],
+		"{day}", day,
+	)
+	return replacer.Replace(locale.DateWordsLayout), nil
+}
+
+// ParseDateWords reads a date written like DateToWords does it.
+// Besides the formal name the month can also have the everyday name of the locale
+func ParseDateWords(s string, locale *Locale) (Date, error) {
+	locale = orEnglish(locale)
+	if locale.NumberWords == nil {
+		return nil, ErrNoNumberWords
+	}
+	var invalid = errors.New("\"" + s + "\" is not a date in words")
+	var fields = map[string]string{}
+	var layout, value = locale.DateWordsLayout, strings.TrimSpace(s)
+	for layout != "" {
+		if !strings.HasPrefix(layout, "{") {
+			var literalEnd = strings.Index(layout, "{")
+			if literalEnd < 0 {
+				literalEnd = len(layout)
+			}
+			if !strings.HasPrefix(value, layout[:literalEnd]) {
+				return nil, invalid
+			}
+			value = value[literalEnd:]
+			layout = layout[literalEnd:]
+			continue
+		}
+		var fieldEnd = strings.Index(layout, "}") + 1
+		var name = layout[1 : fieldEnd-1]
+		layout = layout[fieldEnd:]
+		//the field ends where the following literal text starts
+		var literal = layout
+		if next := strings.Index(layout, "{"); next >= 0 {
+			literal = layout[:next]
+		}
+		var valueEnd = len(value)
+		if literal != "" {
+			valueEnd = strings.Index(value, literal)
+			if valueEnd < 0 {
+				return nil, invalid
+			}
+		}
+		fields[name] = value[:valueEnd]
+		value = value[valueEnd:]
+	}
+	if value != "" {
+		return nil, invalid
+	}
+
+	year, err := ParseNumberWords(fields["year"], locale)
+	if err != nil {
+		return nil, err
+	}
+	day, err := ParseNumberWords(fields["day"], locale)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < 12; i++ {
+		if strings.EqualFold(fields["month"], locale.FormalMonthNames[i]) ||
+			strings.EqualFold(fields["month"], locale.MonthNames[i]) {
+			return New(day, i+1, year)
+		}
+	}
+	return nil, errors.New("\"" + fields["month"] + "\" is not the name of a month")
+}
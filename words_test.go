@@ -0,0 +1,170 @@
+package bsdate
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/magiconair/properties/assert"
+)
+
+func TestNumberToWords(t *testing.T) {
+	var tests = []struct {
+		number  int
+		english string
+		nepali  string
+	}{
+		{0, "Zero", "शून्य"},
+		{7, "Seven", "सात"},
+		{15, "Fifteen", "पन्ध्र"},
+		{30, "Thirty", "तीस"},
+		{32, "Thirty-Two", "बत्तीस"},
+		{99, "Ninety-Nine", "उनान्सय"},
+		{100, "One Hundred", "एक सय"},
+		{2081, "Two Thousand Eighty-One", "दुई हजार एकासी"},
+		{2100, "Two Thousand One Hundred", "दुई हजार एक सय"},
+		{150000, "One Hundred Fifty Thousand", "एक लाख पचास हजार"},
+		{12345678, "Twelve Million Three Hundred Forty-Five Thousand Six Hundred Seventy-Eight",
+			"एक करोड तेइस लाख पैंतालीस हजार छ सय अठहत्तर"},
+		{-5, "Minus Five", "ऋण पाँच"},
+	}
+	for _, test := range tests {
+		t.Run(test.english, func(t *testing.T) {
+			english, err := NumberToWords(test.number, English)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, english, test.english)
+			nepali, err := NumberToWords(test.number, Nepali)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, nepali, test.nepali)
+
+			parsed, err := ParseNumberWords(test.english, English)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, parsed, test.number)
+			parsed, err = ParseNumberWords(test.nepali, Nepali)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, parsed, test.number)
+		})
+	}
+}
+
+func TestNumberWordsSmallestInt(t *testing.T) {
+	var words = strings.Join(English.NumberWords.spell(math.MinInt64), " ")
+	assert.Equal(t, words, "Minus Nine Billion Two Hundred Twenty-Three Million Three Hundred Seventy-Two Thousand "+
+		"Thirty-Six Billion Eight Hundred Fifty-Four Million Seven Hundred Seventy-Five Thousand Eight Hundred Eight")
+	_, err := NumberToWords(-1<<(strconv.IntSize-1), English)
+	assert.Equal(t, err, nil)
+}
+
+func TestNumberWordsRoundTrip(t *testing.T) {
+	for n := 0; n <= 3000; n++ {
+		for _, locale := range []*Locale{English, Nepali} {
+			words, _ := NumberToWords(n, locale)
+			parsed, err := ParseNumberWords(words, locale)
+			if err != nil || parsed != n {
+				t.Fatalf("%d was written as %q and read as %d, %v", n, words, parsed, err)
+			}
+		}
+	}
+}
+
+func TestParseNumberWords(t *testing.T) {
+	var tests = []struct {
+		words    string
+		locale   *Locale
+		expected int
+		err      string
+	}{
+		{"two thousand eighty-one", English, 2081, ""},
+		{"  Eighty  one ", English, 81, ""},
+		{"दस", Nepali, 10, ""},
+		{"Twenty Thirty", English, 0, "\"Twenty Thirty\" is not a number in words"},
+		{"Hundred", English, 0, "\"Hundred\" is not a number in words"},
+		{"One Thousand Thousand", English, 0, "\"One Thousand Thousand\" is not a number in words"},
+		{"एक नौ", Nepali, 0, "\"एक नौ\" is not a number in words"},
+		{"", English, 0, "\"\" is not a number in words"},
+		{"Eleventy", English, 0, "\"Eleventy\" is not a number in words"},
+	}
+	for _, test := range tests {
+		t.Run(test.words, func(t *testing.T) {
+			parsed, err := ParseNumberWords(test.words, test.locale)
+			if test.err != "" {
+				assert.Equal(t, err.Error(), test.err)
+				return
+			}
+			assert.Equal(t, err, nil)
+			assert.Equal(t, parsed, test.expected)
+		})
+	}
+}
+
+func TestNoNumberWords(t *testing.T) {
+	var locale = &Locale{}
+	_, err := NumberToWords(1, locale)
+	assert.Equal(t, err, ErrNoNumberWords)
+	_, err = ParseNumberWords("One", locale)
+	assert.Equal(t, err, ErrNoNumberWords)
+	_, err = DateToWords(mustNew(t, "2081-01-01"), locale)
+	assert.Equal(t, err, ErrNoNumberWords)
+}
+
+func TestWordsNilLocale(t *testing.T) {
+	words, err := NumberToWords(21, nil)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, words, "Twenty-One")
+	n, err := ParseNumberWords("Twenty-One", nil)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, n, 21)
+	words, err = DateToWords(mustNew(t, "2081-01-15"), nil)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, words, "Two Thousand Eighty-One, Baisakh Fifteen")
+	d, err := ParseDateWords(words, nil)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, formatForTest(d), "2081-01-15")
+}
+
+func TestDateToWords(t *testing.T) {
+	var tests = []struct {
+		date    string
+		english string
+		nepali  string
+	}{
+		{"2081-01-15", "Two Thousand Eighty-One, Baisakh Fifteen", "दुई हजार एकासी साल वैशाख पन्ध्र गते"},
+		{"2100-12-30", "Two Thousand One Hundred, Chaitra Thirty", "दुई हजार एक सय साल चैत्र तीस गते"},
+		{"2081-08-01", "Two Thousand Eighty-One, Mangsir One", "दुई हजार एकासी साल मार्गशीर्ष एक गते"},
+	}
+	for _, test := range tests {
+		t.Run(test.date, func(t *testing.T) {
+			var d = mustNew(t, test.date)
+			english, err := DateToWords(d, English)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, english, test.english)
+			nepali, err := DateToWords(d, Nepali)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, nepali, test.nepali)
+
+			parsed, err := ParseDateWords(test.english, English)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, formatForTest(parsed), test.date)
+			parsed, err = ParseDateWords(test.nepali, Nepali)
+			assert.Equal(t, err, nil)
+			assert.Equal(t, formatForTest(parsed), test.date)
+		})
+	}
+}
+
+func TestParseDateWords(t *testing.T) {
+	parsed, err := ParseDateWords("दुई हजार एकासी साल चैत तीस गते", Nepali)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, formatForTest(parsed), "2081-12-30")
+	parsed, err = ParseDateWords(" two thousand eighty-one, baisakh one ", English)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, formatForTest(parsed), "2081-01-01")
+
+	_, err = ParseDateWords("Two Thousand Eighty-One Baisakh One", English)
+	assert.Equal(t, err.Error(), "\"Two Thousand Eighty-One Baisakh One\" is not a date in words")
+	_, err = ParseDateWords("Two Thousand Eighty-One, Someday One", English)
+	assert.Equal(t, err.Error(), "\"Someday\" is not the name of a month")
+	_, err = ParseDateWords("Two Thousand Eighty-One, Baisakh Thirty-Two", English)
+	assert.Equal(t, err, ErrInvalidDate)
+}